@@ -0,0 +1,120 @@
+package cluedo
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+const benchTurns = 50
+
+// benchGame deals a random game and plays turns into it. The same seed always
+// gives the same game.
+func benchGame(opponents int, turns int) hiddenDeal {
+	rng := rand.New(rand.NewPCG(1, uint64(opponents)))
+	deal := dealRandomGame(rng, opponents)
+	for range turns {
+		for _, q := range deal.randomTurn(rng) {
+			deal.game.DoTurn(q)
+		}
+	}
+	return deal
+}
+
+func benchPlayerCounts(b *testing.B, bench func(b *testing.B, opponents int)) {
+	for _, opponents := range []int{2, 5} {
+		b.Run(fmt.Sprintf("players=%d", opponents+1), func(b *testing.B) {
+			bench(b, opponents)
+		})
+	}
+}
+
+func BenchmarkGame(b *testing.B) {
+	benchPlayerCounts(b, func(b *testing.B, opponents int) {
+		b.ResetTimer()
+		for range b.N {
+			benchGame(opponents, benchTurns)
+		}
+	})
+}
+
+func BenchmarkDoTurn(b *testing.B) {
+	benchPlayerCounts(b, func(b *testing.B, opponents int) {
+		rng := rand.New(rand.NewPCG(2, uint64(opponents)))
+		deal := benchGame(opponents, benchTurns/2)
+		questions := []Question{}
+		for range 20 {
+			questions = append(questions, deal.randomTurn(rng)...)
+		}
+
+		b.ResetTimer()
+		for range b.N {
+			b.StopTimer()
+			game, _ := deal.game.clone()
+			b.StartTimer()
+
+			for _, q := range questions {
+				game.DoTurn(q)
+			}
+		}
+	})
+}
+
+// BenchmarkUpdate runs a deduction pass over a game that's already up to
+// date, which is the cost every turn pays before it can stop.
+func BenchmarkUpdate(b *testing.B) {
+	benchPlayerCounts(b, func(b *testing.B, opponents int) {
+		deal := benchGame(opponents, benchTurns)
+		b.ResetTimer()
+		for range b.N {
+			deal.game.Update()
+		}
+	})
+}
+
+func BenchmarkString(b *testing.B) {
+	benchPlayerCounts(b, func(b *testing.B, opponents int) {
+		deal := benchGame(opponents, benchTurns)
+		b.ResetTimer()
+		for range b.N {
+			_ = deal.game.String()
+		}
+	})
+}
+
+func BenchmarkSnapshot(b *testing.B) {
+	benchPlayerCounts(b, func(b *testing.B, opponents int) {
+		deal := benchGame(opponents, benchTurns)
+		b.ResetTimer()
+		for range b.N {
+			deal.game.Snapshot()
+		}
+	})
+}
+
+func BenchmarkClone(b *testing.B) {
+	benchPlayerCounts(b, func(b *testing.B, opponents int) {
+		deal := benchGame(opponents, benchTurns)
+		b.ResetTimer()
+		for range b.N {
+			deal.game.Clone()
+		}
+	})
+}
+
+func BenchmarkProbabilities(b *testing.B) {
+	for _, turns := range []int{0, benchTurns / 5, benchTurns} {
+		b.Run(fmt.Sprintf("turns=%d", turns), func(b *testing.B) {
+			benchPlayerCounts(b, func(b *testing.B, opponents int) {
+				deal := benchGame(opponents, turns)
+				b.ResetTimer()
+				for range b.N {
+					if _, err := deal.game.Probabilities(context.Background(), ProbabilityOptions{}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
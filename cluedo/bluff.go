@@ -0,0 +1,58 @@
+package cluedo
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Bluff is how likely a player is to be holding a card they've suggested.
+type Bluff struct {
+	Asker string
+	Card  string
+	// how many separate suggestions they've put the card in
+	Times int
+	// the chance they hold it
+	Held float64
+}
+
+// Bluffs reads every card the other players have suggested against probs,
+// most likely to be their own first. Counting probs with a BluffRate or
+// priors is what lets suggesting a card over and over make it look more like
+// the asker's own.
+func (g *Game) Bluffs(probs Probabilities) []Bluff {
+	times := map[[2]int]int{}
+	for _, s := range g.suggestions() {
+		if g.PlayerByID(s.asker) == g.Me {
+			continue
+		}
+		for _, c := range s.cards {
+			times[[2]int{int(s.asker), int(c)}]++
+		}
+	}
+
+	bluffs := []Bluff{}
+	for key, n := range times {
+		asker := g.players[key[0]].name
+		card := g.cards[key[1]].name
+		bluffs = append(bluffs, Bluff{
+			Asker: asker,
+			Card:  card,
+			Times: n,
+			Held:  probs.Owner(card, asker),
+		})
+	}
+	slices.SortFunc(bluffs, func(a, b Bluff) int {
+		if c := cmp.Compare(b.Held, a.Held); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Times, a.Times); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Asker, b.Asker); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Card, b.Card)
+	})
+	return bluffs
+}
@@ -0,0 +1,311 @@
+package cluedo
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Board is the cards of an edition of the game and how far apart its rooms
+// are. Players can only suggest the room they're in so the board decides
+// which suggestions can be made next turn.
+type Board struct {
+	edition  string
+	suspects []string
+	weapons  []string
+	rooms    []string
+
+	// steps[i][j] is the fewest corridor squares between a door of room i
+	// and a door of room j
+	steps [][]int
+	// passage[i] is the room a secret passage from room i leads to, or -1
+	passage []int
+
+	// the suspects in the order they take their turns
+	turnOrder []string
+}
+
+func NewBoard(edition string, rooms ...string) *Board {
+	b := &Board{
+		edition: edition,
+		rooms:   slices.Clone(rooms),
+	}
+	for range rooms {
+		b.steps = append(b.steps, make([]int, len(rooms)))
+		b.passage = append(b.passage, -1)
+	}
+	return b
+}
+
+func (b *Board) Edition() string {
+	return b.edition
+}
+
+func (b *Board) Suspects() []string {
+	return slices.Clone(b.suspects)
+}
+
+func (b *Board) Weapons() []string {
+	return slices.Clone(b.weapons)
+}
+
+func (b *Board) Rooms() []string {
+	return slices.Clone(b.rooms)
+}
+
+// SetSuspects sets the who cards of the edition.
+func (b *Board) SetSuspects(suspects ...string) {
+	b.suspects = slices.Clone(suspects)
+}
+
+// SetWeapons sets the what cards of the edition.
+func (b *Board) SetWeapons(weapons ...string) {
+	b.weapons = slices.Clone(weapons)
+}
+
+func (b *Board) room(name string) (int, error) {
+	i := slices.Index(b.rooms, name)
+	if i < 0 {
+		return 0, fmt.Errorf("`%s` isn't a room on the %s board", name, b.edition)
+	}
+	return i, nil
+}
+
+func (b *Board) SetDistance(from, to string, steps int) error {
+	i, err := b.room(from)
+	if err != nil {
+		return err
+	}
+	j, err := b.room(to)
+	if err != nil {
+		return err
+	}
+	if i == j || steps <= 0 {
+		return fmt.Errorf("can't have %d steps between %s and %s", steps, from, to)
+	}
+
+	b.steps[i][j] = steps
+	b.steps[j][i] = steps
+	return nil
+}
+
+func (b *Board) AddSecretPassage(from, to string) error {
+	i, err := b.room(from)
+	if err != nil {
+		return err
+	}
+	j, err := b.room(to)
+	if err != nil {
+		return err
+	}
+	if b.passage[i] != -1 || b.passage[j] != -1 {
+		return fmt.Errorf("%s or %s already has a secret passage", from, to)
+	}
+
+	b.passage[i] = j
+	b.passage[j] = i
+	return nil
+}
+
+// SetTurnOrder sets the order the suspects take their turns in.
+func (b *Board) SetTurnOrder(suspects ...string) {
+	b.turnOrder = slices.Clone(suspects)
+}
+
+// Distance is the number of steps from one room to another. It's 0 when
+// they're joined by a secret passage and -1 when there's no way between them.
+func (b *Board) Distance(from, to string) (int, error) {
+	i, err := b.room(from)
+	if err != nil {
+		return 0, err
+	}
+	j, err := b.room(to)
+	if err != nil {
+		return 0, err
+	}
+
+	if i == j || b.passage[i] == j {
+		return 0, nil
+	}
+	if b.steps[i][j] == 0 {
+		return -1, nil
+	}
+	return b.steps[i][j], nil
+}
+
+// SecretPassage gives the room a secret passage from room leads to.
+func (b *Board) SecretPassage(room string) (string, bool) {
+	i, err := b.room(room)
+	if err != nil || b.passage[i] == -1 {
+		return "", false
+	}
+	return b.rooms[b.passage[i]], true
+}
+
+// Reachable lists the rooms that can be entered from room with a roll of
+// roll, in board order. A room can be entered without using the whole roll
+// and the room a secret passage leads to can always be reached instead of
+// rolling. The room being left isn't included.
+func (b *Board) Reachable(room string, roll int) ([]string, error) {
+	i, err := b.room(room)
+	if err != nil {
+		return nil, err
+	}
+
+	reachable := []string{}
+	for j, name := range b.rooms {
+		if i == j {
+			continue
+		}
+		if b.passage[i] == j || (b.steps[i][j] > 0 && b.steps[i][j] <= roll) {
+			reachable = append(reachable, name)
+		}
+	}
+	return reachable, nil
+}
+
+// DefaultBoard is the board for the rooms in NewDefaultGame. The rooms are in
+// a 3x3 grid with secret passages between opposite corners:
+//
+//	bathroom  study       kitchen
+//	bedroom   courtyard   dining room
+//	garage    games room  living room
+func DefaultBoard() *Board {
+	b := NewBoard("default",
+		"bathroom",
+		"study",
+		"dining room",
+		"games room",
+		"garage",
+		"bedroom",
+		"living room",
+		"kitchen",
+		"courtyard",
+	)
+
+	// each row is the distance to the rooms before it
+	steps := [][]int{
+		{},
+		{9},
+		{19, 15},
+		{21, 13, 13},
+		{16, 20, 19, 9},
+		{11, 16, 16, 14, 9},
+		{25, 19, 11, 8, 13, 19},
+		{13, 10, 8, 20, 24, 21, 16},
+		{16, 11, 11, 9, 15, 8, 13, 14},
+	}
+	for i, row := range steps {
+		for j, n := range row {
+			b.steps[i][j] = n
+			b.steps[j][i] = n
+		}
+	}
+
+	b.passage[0], b.passage[6] = 6, 0
+	b.passage[4], b.passage[7] = 7, 4
+
+	b.suspects = standardSuspects
+	b.weapons = []string{"wrench", "candlestick", "dagger", "pistol", "lead pipe", "rope"}
+	b.turnOrder = standardTurnOrder
+
+	return b
+}
+
+// ClassicBoard is the original mansion board. The rooms go round the edge
+// with the cellar in the middle and secret passages across the corners:
+//
+//	kitchen      ballroom  conservatory
+//	dining room  cellar    billiard room
+//	                       library
+//	lounge       hall      study
+func ClassicBoard() *Board {
+	b := NewBoard("classic",
+		"kitchen",
+		"ballroom",
+		"conservatory",
+		"dining room",
+		"billiard room",
+		"library",
+		"lounge",
+		"hall",
+		"study",
+	)
+
+	// each row is the distance to the rooms before it
+	steps := [][]int{
+		{},
+		{7},
+		{20, 4},
+		{11, 7, 19},
+		{17, 6, 7, 14},
+		{23, 12, 14, 14, 4},
+		{19, 15, 25, 4, 22, 14},
+		{19, 13, 18, 8, 15, 7, 8},
+		{25, 17, 20, 17, 15, 7, 17, 4},
+	}
+	for i, row := range steps {
+		for j, n := range row {
+			b.steps[i][j] = n
+			b.steps[j][i] = n
+		}
+	}
+
+	b.passage[0], b.passage[8] = 8, 0
+	b.passage[2], b.passage[6] = 6, 2
+
+	b.suspects = standardSuspects
+	b.weapons = []string{"candlestick", "dagger", "lead piping", "revolver", "rope", "spanner"}
+	b.turnOrder = standardTurnOrder
+
+	return b
+}
+
+func (g *Game) Board() *Board {
+	return g.board
+}
+
+// ReachableRooms lists the where cards that can be suggested next turn from
+// room with a roll of roll.
+func (g *Game) ReachableRooms(room string, roll int) ([]*Card, error) {
+	rooms, err := g.board.Reachable(room, roll)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := []*Card{}
+	for _, c := range g.whereCategory.Cards {
+		if slices.Contains(rooms, c.name) {
+			cards = append(cards, c)
+		}
+	}
+	return cards, nil
+}
+
+var standardSuspects = []string{"green", "mustard", "peacock", "plum", "scarlet", "white"}
+
+// the standard editions go clockwise round the board from Scarlet
+var standardTurnOrder = []string{"scarlet", "mustard", "white", "green", "peacock", "plum"}
+
+var editions = map[string]func() *Board{
+	"default": DefaultBoard,
+	"classic": ClassicBoard,
+}
+
+// Editions lists the names of the editions with a board, in name order.
+func Editions() []string {
+	names := []string{}
+	for name := range editions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// EditionBoard gives a fresh copy of the board for a named edition.
+func EditionBoard(edition string) (*Board, error) {
+	board, ok := editions[edition]
+	if !ok {
+		return nil, fmt.Errorf("unknown edition `%s`", edition)
+	}
+	return board(), nil
+}
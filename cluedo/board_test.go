@@ -0,0 +1,122 @@
+package cluedo_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
+)
+
+func TestEditionBoardsMatchCards(t *testing.T) {
+	for _, edition := range cluedo.Editions() {
+		board, err := cluedo.EditionBoard(edition)
+		if err != nil {
+			t.Fatal(err)
+		}
+		game := cluedo.NewGameWithBoard(board)
+		if game.Board() != board {
+			t.Errorf("NewGameWithBoard() %s game isn't played on its board", edition)
+		}
+
+		rooms := []string{}
+		for _, c := range game.GetAllCards() {
+			if _, err := board.Distance(c.Name(), c.Name()); err == nil {
+				rooms = append(rooms, c.Name())
+			}
+		}
+		if !slices.Equal(rooms, board.Rooms()) {
+			t.Errorf("EditionBoard() %s rooms %v don't match the where cards %v", edition, board.Rooms(), rooms)
+		}
+		if n := len(game.GetAllCards()); n != len(board.Suspects())+len(board.Weapons())+len(board.Rooms()) {
+			t.Errorf("NewGameWithBoard() %s game has %d cards", edition, n)
+		}
+		for _, suspect := range board.Suspects() {
+			if game.Card(suspect) == nil {
+				t.Errorf("NewGameWithBoard() %s game has no card for %s", edition, suspect)
+			}
+		}
+
+		for _, from := range board.Rooms() {
+			for _, to := range board.Rooms() {
+				there, _ := board.Distance(from, to)
+				back, _ := board.Distance(to, from)
+				if there != back || there < 0 {
+					t.Errorf("Board.Distance() %s: %s to %s is %d but back is %d", edition, from, to, there, back)
+				}
+			}
+		}
+	}
+
+	if _, err := cluedo.EditionBoard("deluxe"); err == nil {
+		t.Error("EditionBoard() Didn't error for an edition that doesn't exist.")
+	}
+}
+
+func TestClassicGame(t *testing.T) {
+	board, _ := cluedo.EditionBoard("classic")
+	game := cluedo.NewGameWithBoard(board, cluedo.NewPlayer("alice", 9), cluedo.NewPlayer("bob", 9))
+
+	if game.Card("spanner") == nil || game.Card("wrench") != nil {
+		t.Error("NewGameWithBoard() Classic game should have a spanner instead of a wrench.")
+	}
+	if passage, _ := board.SecretPassage("kitchen"); passage != "study" {
+		t.Errorf("Board.SecretPassage() Expected the kitchen to lead to the study but got %q", passage)
+	}
+
+	q, err := game.QuestionFor(game.Card("plum"), game.Card("rope"), game.Card("library"), game.Me, game.Player("alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.SetAnswer(cluedo.NoAnswer)
+	if _, err := game.DoTurn(q); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(game.Card("library").NonPossessors(), game.Player("alice")) {
+		t.Error("Game.DoTurn() alice passed on the library but wasn't marked as not having it.")
+	}
+}
+
+func TestBoardReachable(t *testing.T) {
+	board := cluedo.NewBoard("test", "hall", "lounge", "cellar", "attic")
+	if err := board.SetDistance("hall", "lounge", 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := board.SetDistance("hall", "cellar", 9); err != nil {
+		t.Fatal(err)
+	}
+	if err := board.AddSecretPassage("hall", "attic"); err != nil {
+		t.Fatal(err)
+	}
+
+	reachable, err := board.Reachable("hall", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(reachable, []string{"lounge", "attic"}) {
+		t.Errorf("Board.Reachable() With a roll of 4 expected lounge and the attic through the passage but got %v", reachable)
+	}
+
+	reachable, _ = board.Reachable("hall", 12)
+	if !slices.Equal(reachable, []string{"lounge", "cellar", "attic"}) {
+		t.Errorf("Board.Reachable() With a roll of 12 expected every other room but got %v", reachable)
+	}
+
+	if d, _ := board.Distance("lounge", "cellar"); d != -1 {
+		t.Errorf("Board.Distance() lounge and cellar aren't joined but the distance was %d", d)
+	}
+	if _, err := board.Reachable("garden", 6); err == nil {
+		t.Error("Board.Reachable() Didn't error for a room that isn't on the board.")
+	}
+}
+
+func TestGameReachableRooms(t *testing.T) {
+	game := cluedo.NewDefaultGame()
+
+	rooms, err := game.ReachableRooms("bathroom", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rooms) != 1 || rooms[0].Name() != "living room" {
+		t.Errorf("Game.ReachableRooms() Only the secret passage to the living room should be reachable with a 2 but got %d rooms", len(rooms))
+	}
+}
@@ -2,17 +2,14 @@ package cluedo
 
 import "slices"
 
+// Card is a view of one card in a game. Cards made with NewCard don't belong
+// to a game and are only used to name a card, so nothing is known about them
+// and setting anything on them does nothing.
 type Card struct {
 	name string
 
-	isMurderItem bool
-
-	found         bool
-	possessor     *Player
-	nonPossessors []*Player
-
-	links    []Link
-	trilinks []TriLink
+	index int
+	k     *knowledge
 }
 
 func NewCard(name string) *Card {
@@ -21,152 +18,110 @@ func NewCard(name string) *Card {
 	}
 }
 
-func (c *Card) SetFound(possessor *Player, destroyLinks bool) {
-	c.found = true
-	c.possessor = possessor
-
-	if destroyLinks {
-		for i, l := range c.links {
-			if l.player != possessor {
-				//set the other half of the link to found
-				l.other.SetFound(l.player, false)
-			}
-			//destroy the link as it's now redundant
-			for j := range l.other.links {
-				if l.other.links[j].other == c && l.other.links[j].player == l.player {
-					l.other.links = slices.Delete(l.other.links, j, j+1)
-					break
-				}
-			}
-			c.links = slices.Delete(c.links, i, i+1)
-		}
-	}
-
-	// resolve trilinks
-	for i, t := range c.trilinks {
-		if t.player != possessor {
-			//shrink the trilink to a normal link
-			t.other1.AddLink(t.player, t.other2)
-			t.other2.AddLink(t.player, t.other1)
-		}
-		//destroy the link as it's now redundant
-		for j := range t.other1.trilinks {
-			if t.other1.trilinks[j].Equals(t) {
-				t.other1.trilinks = slices.Delete(t.other1.trilinks, j, j+1)
-				break
-			}
-		}
-		for j := range t.other2.trilinks {
-			if t.other2.trilinks[j].Equals(t) {
-				t.other2.trilinks = slices.Delete(t.other2.trilinks, j, j+1)
-				break
-			}
-		}
-		c.trilinks = slices.Delete(c.trilinks, i, i+1)
+// attachCards makes cards views over k, numbering them in order.
+func attachCards(k *knowledge, cards []*Card) {
+	for i, c := range cards {
+		c.index = i
+		c.k = k
 	}
 }
 
-func (c Card) IsFound() bool {
-	return c.found
+func (c *Card) Name() string {
+	return c.name
 }
 
-func (c *Card) AddNonPossessor(player *Player) {
-	if slices.Contains(c.nonPossessors, player) {
+func (c *Card) SetFound(possessor *Player) {
+	if c.k == nil {
 		return
 	}
-
-	c.nonPossessors = append(c.nonPossessors, player)
+	c.k.setFound(c.index, c.k.playerIndex(possessor))
 }
 
-func (c *Card) AddLink(player *Player, other *Card) {
-	newLink := Link{
-		player: player,
-		other:  other,
-	}
-
-	if !slices.Contains(c.links, newLink) {
-		c.links = append(c.links, newLink)
-	}
+func (c *Card) IsFound() bool {
+	return c.k != nil && c.k.found.has(c.index)
 }
 
-func (c *Card) AddTriLink(player *Player, one *Card, two *Card) {
-	newLink := TriLink{
-		this: c,
-
-		player: player,
-		other1: one,
-		other2: two,
+func (c *Card) Possessor() *Player {
+	if c.k == nil {
+		return nil
 	}
-
-	if !slices.Contains(c.trilinks, newLink) {
-		c.trilinks = append(c.trilinks, newLink)
+	if p := c.k.owner(c.index); p >= 0 {
+		return c.k.players[p]
 	}
+	return nil
 }
 
-type Link struct {
-	player *Player
-	other  *Card
+func (c *Card) IsMurderItem() bool {
+	return c.k != nil && c.k.murder.has(c.index)
 }
 
-type TriLink struct {
-	this *Card
-
-	player *Player
-	other1 *Card
-	other2 *Card
+func (c *Card) AddNonPossessor(player *Player) {
+	if c.k == nil {
+		return
+	}
+	if player == c.k.envelope && player != nil {
+		c.k.envelopeCandidates = c.k.envelopeCandidates.without(c.index)
+		return
+	}
+	if p := c.k.playerIndex(player); p >= 0 {
+		c.k.possible[p] = c.k.possible[p].without(c.index)
+	}
 }
 
-func (t TriLink) Equals(other TriLink) bool {
-	if t.player != other.player {
+func (c *Card) isNonPossessor(player *Player) bool {
+	if c.k == nil {
 		return false
 	}
-	thisCards := []*Card{
-		t.this,
-		t.other1,
-		t.other2,
-	}
-	otherCards := []*Card{
-		other.this,
-		other.other1,
-		other.other2,
+	if player == c.k.envelope && player != nil {
+		return c.inEnvelopeRuledOut()
 	}
+	p := c.k.playerIndex(player)
+	return p >= 0 && !c.k.possible[p].has(c.index)
+}
 
-	for _, c := range thisCards {
-		if !slices.Contains(otherCards, c) {
-			return false
+func (c *Card) NonPossessors() []*Player {
+	nonPossessors := []*Player{}
+	if c.k == nil {
+		return nonPossessors
+	}
+	for _, p := range c.k.players {
+		if c.isNonPossessor(p) {
+			nonPossessors = append(nonPossessors, p)
 		}
 	}
+	return nonPossessors
+}
 
-	return true
+func (c *Card) inEnvelopeRuledOut() bool {
+	return c.k != nil && !c.k.envelopeCandidates.has(c.index)
 }
 
 type CardCategory struct {
 	Cards []*Card
 }
 
+// NewCardCategory makes a category whose cards aren't part of a game yet.
 func NewCardCategory(cards ...*Card) CardCategory {
+	attachCards(newKnowledge(len(cards), nil, nil), cards)
+
 	return CardCategory{
 		Cards: cards,
 	}
 }
 
-func (c CardCategory) UpdateMurderKnowledge() {
-	foundCards := 0
-
-	var potentialMurderPart *Card
+func (c CardCategory) set() cardSet {
+	set := cardSet(0)
 	for _, card := range c.Cards {
-		if card.isMurderItem {
-			return
-		}
-		if card.IsFound() {
-			foundCards++
-		} else {
-			potentialMurderPart = card
-		}
+		set = set.with(card.index)
 	}
-	if len(c.Cards)-foundCards == 1 {
-		potentialMurderPart.isMurderItem = true
+	return set
+}
+
+func (c CardCategory) UpdateMurderKnowledge() {
+	if len(c.Cards) == 0 {
+		return
 	}
+	c.Cards[0].k.solveCategory(c.set())
 }
 
 func (c CardCategory) HasKnownSolution() bool {
@@ -176,7 +131,7 @@ func (c CardCategory) GetKnownSolution() *Card {
 	c.UpdateMurderKnowledge()
 	var murderCard *Card
 	for _, card := range c.Cards {
-		if card.isMurderItem {
+		if card.IsMurderItem() {
 			if murderCard != nil {
 				panic("Something has gone wrong. There are 2 cards from 1 category that are the solution.")
 			}
@@ -187,11 +142,10 @@ func (c CardCategory) GetKnownSolution() *Card {
 }
 
 func (c *CardCategory) FoundCard(foundCard *Card, possessor *Player) (success bool) {
-	for _, card := range c.Cards {
-		if foundCard.name == card.name {
-			card.SetFound(possessor, true)
-			return true
-		}
+	i := slices.IndexFunc(c.Cards, func(card *Card) bool { return card.name == foundCard.name })
+	if i < 0 {
+		return false
 	}
-	return false
+	c.Cards[i].SetFound(possessor)
+	return true
 }
@@ -0,0 +1,193 @@
+package cluedo
+
+import "slices"
+
+// Clause records that a player holds at least one of a set of cards. A
+// player showing an unseen card for a suggestion gives a clause over the 3
+// cards in it but a clause can be over any number of cards.
+type Clause struct {
+	player *Player
+	cards  []*Card
+}
+
+func NewClause(player *Player, cards ...*Card) Clause {
+	return Clause{
+		player: player,
+		cards:  slices.Clone(cards),
+	}
+}
+
+func (c Clause) Player() *Player {
+	return c.player
+}
+
+func (c Clause) Cards() []*Card {
+	return slices.Clone(c.cards)
+}
+
+func (c Clause) Equals(other Clause) bool {
+	return c.player == other.player && len(c.cards) == len(other.cards) && c.subsetOf(other)
+}
+
+func (c Clause) subsetOf(other Clause) bool {
+	if c.player != other.player {
+		return false
+	}
+	for _, card := range c.cards {
+		if !slices.Contains(other.cards, card) {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *Game) cardClause(c Clause) cardClause {
+	clause := cardClause{
+		player: g.k.playerIndex(c.player),
+	}
+	for _, card := range c.cards {
+		clause.cards = clause.cards.with(card.index)
+	}
+	return clause
+}
+
+func (g *Game) clause(c cardClause) Clause {
+	clause := Clause{
+		player: g.players[c.player],
+	}
+	for _, i := range c.cards.indices() {
+		clause.cards = append(clause.cards, g.cards[i])
+	}
+	return clause
+}
+
+// AddClause records that player holds at least one of cards. It gets
+// simplified against everything else that's known on the next update.
+func (g *Game) AddClause(player *Player, cards ...*Card) {
+	clause := g.cardClause(NewClause(player, cards...))
+	if clause.player < 0 || slices.Contains(g.k.clauses, clause) {
+		return
+	}
+	g.k.clauses = append(g.k.clauses, clause)
+}
+
+func (g *Game) Clauses() []Clause {
+	clauses := []Clause{}
+	for _, c := range g.k.clauses {
+		clauses = append(clauses, g.clause(c))
+	}
+	return clauses
+}
+
+func (g *Game) ClausesFor(player *Player) []Clause {
+	clauses := []Clause{}
+	for _, c := range g.k.clauses {
+		if g.players[c.player] == player {
+			clauses = append(clauses, g.clause(c))
+		}
+	}
+	return clauses
+}
+
+// UpdateClauses drops every card from a clause that the player is known not
+// to have. Clauses that the player is known to satisfy are removed and
+// clauses with a single card left are resolved by marking it as found.
+func (g *Game) UpdateClauses() {
+	k := g.k
+	remaining := []cardClause{}
+
+	for _, clause := range k.clauses {
+		if clause.cards&k.owned[clause.player] != 0 {
+			continue
+		}
+
+		possible := clause.cards & k.possible[clause.player] &^ k.found &^ k.murder
+		switch possible.len() {
+		case 0:
+			// the player can't have any of the cards so something was
+			// entered wrong. There's nothing sound left to deduce from it
+			continue
+		case 1:
+			k.setFound(possible.first(), clause.player)
+		default:
+			remaining = append(remaining, cardClause{
+				player: clause.player,
+				cards:  possible,
+			})
+		}
+	}
+
+	// a clause that contains a smaller one for the same player tells us
+	// nothing new
+	k.clauses = []cardClause{}
+	for i, clause := range remaining {
+		redundant := false
+		for j, other := range remaining {
+			if i == j || other.player != clause.player || other.cards&^clause.cards != 0 {
+				continue
+			}
+			// keep the first of two identical clauses
+			if other != clause || j < i {
+				redundant = true
+				break
+			}
+		}
+		if !redundant {
+			k.clauses = append(k.clauses, clause)
+		}
+	}
+}
+
+// UpdateClauseCardinality rules out cards using how many cards a player has
+// left to find. If they have n cards left and n clauses with no cards in
+// common then each of those clauses accounts for one of their cards, so
+// they can't have anything outside of them.
+func (g *Game) UpdateClauseCardinality() {
+	k := g.k
+
+	for p, player := range g.players {
+		slotsLeft := player.cardCount - k.owned[p].len()
+
+		// a clause can be satisfied by a card found since the clauses were
+		// last simplified, which would count that card twice
+		clauses := []cardClause{}
+		for _, clause := range k.clauses {
+			if clause.player == p && clause.cards&k.owned[p] == 0 {
+				clauses = append(clauses, clause)
+			}
+		}
+		if slotsLeft <= 0 || len(clauses) < slotsLeft {
+			continue
+		}
+
+		for _, set := range disjointClauseSets(clauses, slotsLeft) {
+			covered := cardSet(0)
+			for _, clause := range set {
+				covered |= clause.cards
+			}
+			k.possible[p] &= covered | k.found
+		}
+	}
+}
+
+// disjointClauseSets finds every way of picking size clauses that don't
+// share any cards.
+func disjointClauseSets(clauses []cardClause, size int) [][]cardClause {
+	sets := [][]cardClause{}
+
+	var pick func(start int, chosen []cardClause, used cardSet)
+	pick = func(start int, chosen []cardClause, used cardSet) {
+		if len(chosen) == size {
+			sets = append(sets, slices.Clone(chosen))
+			return
+		}
+		for i := start; i < len(clauses); i++ {
+			if clauses[i].cards&used == 0 {
+				pick(i+1, append(chosen, clauses[i]), used|clauses[i].cards)
+			}
+		}
+	}
+	pick(0, []cardClause{}, 0)
+
+	return sets
+}
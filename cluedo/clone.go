@@ -0,0 +1,72 @@
+package cluedo
+
+import (
+	"maps"
+	"slices"
+)
+
+// Clone makes a deep copy of the game. Nothing done to the copy changes the
+// original or the other way round.
+func (g *Game) Clone() Game {
+	clone, _ := g.clone()
+	return clone
+}
+
+func (g *Game) clone() (Game, map[*Player]*Player) {
+	players := map[*Player]*Player{}
+	clonePlayer := func(p *Player) *Player {
+		if p == nil {
+			return nil
+		}
+		if clone, ok := players[p]; ok {
+			return clone
+		}
+		clone := *p
+		players[p] = &clone
+		return &clone
+	}
+
+	cloneCategory := func(category CardCategory) CardCategory {
+		clone := CardCategory{}
+		for _, c := range category.Cards {
+			clone.Cards = append(clone.Cards, NewCard(c.name))
+		}
+		return clone
+	}
+
+	clone := Game{
+		Me:              clonePlayer(g.Me),
+		Envelope:        clonePlayer(g.Envelope),
+		board:           g.board,
+		k:               g.k.clone(),
+		tokenRooms:      maps.Clone(g.tokenRooms),
+		playerRooms:     maps.Clone(g.playerRooms),
+		suspects:        maps.Clone(g.suspects),
+		hand:            g.hand,
+		history:         slices.Clone(g.history),
+		turn:            g.turn,
+		constraintTurns: maps.Clone(g.constraintTurns),
+	}
+	for _, p := range g.players {
+		clone.players = append(clone.players, clonePlayer(p))
+	}
+	clone.k.players = clone.players
+	clone.k.envelope = clone.Envelope
+
+	clone.whoCategory = cloneCategory(g.whoCategory)
+	clone.whatCategory = cloneCategory(g.whatCategory)
+	clone.whereCategory = cloneCategory(g.whereCategory)
+	clone.cards = clone.GetAllCards()
+	attachCards(clone.k, clone.cards)
+
+	return clone, players
+}
+
+// WhatIf works out what would be learnt if question got answer, without
+// changing the game.
+func (g *Game) WhatIf(question Question, answer Answer) (TurnResult, error) {
+	clone, _ := g.clone()
+
+	question.SetAnswer(answer)
+	return clone.DoTurn(question)
+}
@@ -16,6 +16,21 @@ func lookupCard(t *testing.T, game Game, cardName string) (found *Card) {
 	return nil
 }
 
+func hasClause(game Game, player *Player, cards ...*Card) bool {
+	return slices.ContainsFunc(game.Clauses(), NewClause(player, cards...).Equals)
+}
+
+// newQuestion makes a question in g from cards and players that may not be
+// g's own.
+func newQuestion(t testing.TB, g *Game, who, what, where *Card, asker, answerer *Player) Question {
+	t.Helper()
+	q, err := g.QuestionFor(who, what, where, asker, answerer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
 func GenSampleGame() (game Game, a, b, c *Player) {
 	a = NewPlayer("alice", 4)
 	b = NewPlayer("bob", 4)
@@ -28,7 +43,7 @@ func GenSampleGame() (game Game, a, b, c *Player) {
 func TestTurnWhoAnswerUpdatesFound(t *testing.T) {
 	game, alice, _, _ := GenSampleGame()
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("white"),
 		NewCard("pistol"),
 		NewCard("bedroom"),
@@ -58,7 +73,7 @@ func TestTurnWhoAnswerUpdatesFound(t *testing.T) {
 func TestTurnWhatAnswerUpdatesFound(t *testing.T) {
 	game, alice, _, _ := GenSampleGame()
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("white"),
 		NewCard("pistol"),
 		NewCard("bedroom"),
@@ -88,7 +103,7 @@ func TestTurnWhatAnswerUpdatesFound(t *testing.T) {
 func TestTurnWhereAnswerUpdatesFound(t *testing.T) {
 	game, alice, _, _ := GenSampleGame()
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("white"),
 		NewCard("pistol"),
 		NewCard("bedroom"),
@@ -118,7 +133,7 @@ func TestTurnWhereAnswerUpdatesFound(t *testing.T) {
 func TestTurnWhoAnswerPosessor(t *testing.T) {
 	game, alice, _, _ := GenSampleGame()
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("white"),
 		NewCard("pistol"),
 		NewCard("bedroom"),
@@ -130,7 +145,7 @@ func TestTurnWhoAnswerPosessor(t *testing.T) {
 	game.DoTurn(question)
 
 	whiteCard := lookupCard(t, game, "white")
-	if whiteCard.possessor != alice {
+	if whiteCard.Possessor() != alice {
 		t.Error("Game.DoTurn() Was shown a who card by alice but she wasn't marked as the owner of the card.")
 	}
 }
@@ -138,7 +153,7 @@ func TestTurnWhoAnswerPosessor(t *testing.T) {
 func TestTurnWhatAnswerPosessor(t *testing.T) {
 	game, alice, _, _ := GenSampleGame()
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("white"),
 		NewCard("pistol"),
 		NewCard("bedroom"),
@@ -150,7 +165,7 @@ func TestTurnWhatAnswerPosessor(t *testing.T) {
 	game.DoTurn(question)
 
 	pistolCard := lookupCard(t, game, "pistol")
-	if pistolCard.possessor != alice {
+	if pistolCard.Possessor() != alice {
 		t.Error("Game.DoTurn() Was shown a what card by alice but she wasn't marked as the owner of the card.")
 	}
 }
@@ -158,7 +173,7 @@ func TestTurnWhatAnswerPosessor(t *testing.T) {
 func TestTurnWhereAnswerPosessor(t *testing.T) {
 	game, alice, _, _ := GenSampleGame()
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("white"),
 		NewCard("pistol"),
 		NewCard("bedroom"),
@@ -170,7 +185,7 @@ func TestTurnWhereAnswerPosessor(t *testing.T) {
 	game.DoTurn(question)
 
 	bedroomCard := lookupCard(t, game, "bedroom")
-	if bedroomCard.possessor != alice {
+	if bedroomCard.Possessor() != alice {
 		t.Error("Game.DoTurn() Was shown a where card by alice but she wasn't marked as the owner of the card.")
 	}
 }
@@ -185,7 +200,7 @@ func TestStartingHandOneCard(t *testing.T) {
 	if !pipeCard.IsFound() {
 		t.Error("Game.AddStartingHand() Started with 1 card but it wasn't marked as found.")
 	}
-	if pipeCard.possessor != game.Me {
+	if pipeCard.Possessor() != game.Me {
 		t.Error("Game.AddStartingHand() Started with 1 card but its owner wasn't THIS.")
 	}
 }
@@ -216,7 +231,7 @@ func TestStartingHandMultipleCards(t *testing.T) {
 func TestTurnNonPossessor(t *testing.T) {
 	game, alice, _, _ := GenSampleGame()
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("white"),
 		NewCard("pistol"),
 		NewCard("bedroom"),
@@ -228,15 +243,15 @@ func TestTurnNonPossessor(t *testing.T) {
 	game.DoTurn(question)
 
 	whoCard := lookupCard(t, game, "white")
-	if !slices.Contains(whoCard.nonPossessors, alice) {
+	if !whoCard.isNonPossessor(alice) {
 		t.Error("Game.DoTurn() Alice couldn't answer the question but she wasn't marked as not having the person")
 	}
 	whatCard := lookupCard(t, game, "pistol")
-	if !slices.Contains(whatCard.nonPossessors, alice) {
+	if !whatCard.isNonPossessor(alice) {
 		t.Error("Game.DoTurn() Alice couldn't answer the question but she wasn't marked as not having the weapon")
 	}
 	whereCard := lookupCard(t, game, "bedroom")
-	if !slices.Contains(whereCard.nonPossessors, alice) {
+	if !whereCard.isNonPossessor(alice) {
 		t.Error("Game.DoTurn() Alice couldn't answer the question but she wasn't marked as not having the location")
 	}
 }
@@ -248,7 +263,7 @@ func TestUnkownAnswerWith2SimpleSelfKnown(t *testing.T) {
 		NewCard("dagger"),
 	})
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -260,7 +275,7 @@ func TestUnkownAnswerWith2SimpleSelfKnown(t *testing.T) {
 	game.DoTurn(question)
 
 	whereCard := lookupCard(t, game, "bedroom")
-	if whereCard.possessor != alice {
+	if whereCard.Possessor() != alice {
 		t.Error("Game.analyseUnknownAnswer() I had 2 cards and alice showed a card when asked about them but the 3rd wasn't marked as hers.")
 	}
 }
@@ -268,7 +283,7 @@ func TestUnkownAnswerWith2SimpleSelfKnown(t *testing.T) {
 func TestUnkownAnswerWith2SimpleOthersKnown(t *testing.T) {
 	game, alice, bob, charlie := GenSampleGame()
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -278,7 +293,7 @@ func TestUnkownAnswerWith2SimpleOthersKnown(t *testing.T) {
 	question.SetAnswer(WhoAnswer)
 	game.DoTurn(question)
 
-	question = NewQuestion(
+	question = newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -288,7 +303,7 @@ func TestUnkownAnswerWith2SimpleOthersKnown(t *testing.T) {
 	question.SetAnswer(WhatAnswer)
 	game.DoTurn(question)
 
-	question = NewQuestion(
+	question = newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -299,7 +314,7 @@ func TestUnkownAnswerWith2SimpleOthersKnown(t *testing.T) {
 	game.DoTurn(question)
 
 	whereCard := lookupCard(t, game, "bedroom")
-	if whereCard.possessor != charlie {
+	if whereCard.Possessor() != charlie {
 		t.Error("Game.analyseUnknownAnswer() 2 cards were in known locations and charlie showed a card when asked about them but the 3rd wasn't marked as his.")
 	}
 }
@@ -310,7 +325,7 @@ func TestUnkownAnswerWith2KnownsAssumptions(t *testing.T) {
 		NewCard("green"),
 	})
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -320,7 +335,7 @@ func TestUnkownAnswerWith2KnownsAssumptions(t *testing.T) {
 	question.SetAnswer(WhatAnswer)
 	game.DoTurn(question)
 
-	question = NewQuestion(
+	question = newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -331,7 +346,7 @@ func TestUnkownAnswerWith2KnownsAssumptions(t *testing.T) {
 	game.DoTurn(question)
 
 	whereCard := lookupCard(t, game, "bedroom")
-	if whereCard.possessor == charlie {
+	if whereCard.Possessor() == charlie {
 		t.Error("Game.analyseUnknownAnswer() 2 cards were in known locations but one was charlie's and charlie showed a card when asked about them and the 3rd was incorrectly assumed to have been shown.")
 	}
 }
@@ -342,7 +357,7 @@ func TestUnkownAnswerWith1Known(t *testing.T) {
 		NewCard("green"),
 	})
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -355,27 +370,18 @@ func TestUnkownAnswerWith1Known(t *testing.T) {
 	daggerCard := lookupCard(t, game, "dagger")
 	bedroomCard := lookupCard(t, game, "bedroom")
 
-	daggerLink := Link{
-		player: charlie,
-		other:  bedroomCard,
-	}
-	bedroomLink := Link{
-		player: charlie,
-		other:  daggerCard,
-	}
-
-	if !slices.Contains(daggerCard.links, daggerLink) {
-		t.Error("Game.analyseUnknownAnswer() 1 card was in a known location but Dagger didn't have Bedroom as a link")
+	if !hasClause(game, charlie, daggerCard, bedroomCard) {
+		t.Error("Game.analyseUnknownAnswer() 1 card was in a known location but charlie didn't have a link between Dagger and Bedroom")
 	}
-	if !slices.Contains(bedroomCard.links, bedroomLink) {
-		t.Error("Game.analyseUnknownAnswer() 1 card was in a known location but Bedroom didn't have Dagger as a link")
+	if len(game.Clauses()) != 1 {
+		t.Errorf("Game.analyseUnknownAnswer() Expected only the link between Dagger and Bedroom but there were %d clauses", len(game.Clauses()))
 	}
 }
 
 func TestUnkownAnswerWith0Knowns(t *testing.T) {
 	game, _, bob, charlie := GenSampleGame()
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -389,33 +395,8 @@ func TestUnkownAnswerWith0Knowns(t *testing.T) {
 	daggerCard := lookupCard(t, game, "dagger")
 	bedroomCard := lookupCard(t, game, "bedroom")
 
-	greenLink := TriLink{
-		this:   greenCard,
-		player: charlie,
-		other1: bedroomCard,
-		other2: daggerCard,
-	}
-	daggerLink := TriLink{
-		this:   daggerCard,
-		player: charlie,
-		other1: bedroomCard,
-		other2: greenCard,
-	}
-	bedroomLink := TriLink{
-		this:   bedroomCard,
-		player: charlie,
-		other1: daggerCard,
-		other2: greenCard,
-	}
-
-	if !slices.ContainsFunc(greenCard.trilinks, func(t TriLink) bool { return t.Equals(greenLink) }) {
-		t.Error("Game.analyseUnknownAnswer() no card was in a known location but Green didn't have Dagger and Bedroom as a trilink")
-	}
-	if !slices.ContainsFunc(daggerCard.trilinks, func(t TriLink) bool { return t.Equals(daggerLink) }) {
-		t.Error("Game.analyseUnknownAnswer() no card was in a known location but Dagger didn't have Bedroom and Green as a trilink")
-	}
-	if !slices.ContainsFunc(bedroomCard.trilinks, func(t TriLink) bool { return t.Equals(bedroomLink) }) {
-		t.Error("Game.analyseUnknownAnswer() no card was in a known location but Bedroom didn't have Green and Dagger as a trilink")
+	if !hasClause(game, charlie, greenCard, daggerCard, bedroomCard) {
+		t.Error("Game.analyseUnknownAnswer() no card was in a known location but charlie didn't have a trilink between Green, Dagger and Bedroom")
 	}
 }
 
@@ -425,7 +406,7 @@ func TestLinkResolutionWithFind(t *testing.T) {
 		NewCard("green"),
 	})
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -435,7 +416,7 @@ func TestLinkResolutionWithFind(t *testing.T) {
 	question.SetAnswer(UnknownAnswer)
 	game.DoTurn(question)
 
-	question = NewQuestion(
+	question = newQuestion(t, &game,
 		NewCard("peacock"),
 		NewCard("dagger"),
 		NewCard("living room"),
@@ -451,23 +432,12 @@ func TestLinkResolutionWithFind(t *testing.T) {
 	if !bedroomCard.IsFound() {
 		t.Error("Charlie had either the bedroom or the dagger and we know alice has the dagger but the bedroom wasn't marked as found")
 	}
-	if bedroomCard.possessor != charlie {
+	if bedroomCard.Possessor() != charlie {
 		t.Error("Charlie had either the bedroom or the dagger and we know alice has the dagger but charlie wasn't the possessor of the bedroom")
 	}
 
-	daggerLink := Link{
-		player: charlie,
-		other:  bedroomCard,
-	}
-	bedroomLink := Link{
-		player: charlie,
-		other:  daggerCard,
-	}
-	if slices.Contains(daggerCard.links, daggerLink) {
-		t.Error("The dagger's link has served it's purpose but it wasn't removed")
-	}
-	if slices.Contains(bedroomCard.links, bedroomLink) {
-		t.Error("The bedroom's link has served it's purpose but it wasn't removed")
+	if hasClause(game, charlie, daggerCard, bedroomCard) {
+		t.Error("The link between the dagger and the bedroom has served it's purpose but it wasn't removed")
 	}
 }
 
@@ -477,7 +447,7 @@ func TestLinkResolutionWithoutFind(t *testing.T) {
 		NewCard("green"),
 	})
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -487,7 +457,7 @@ func TestLinkResolutionWithoutFind(t *testing.T) {
 	question.SetAnswer(UnknownAnswer)
 	game.DoTurn(question)
 
-	question = NewQuestion(
+	question = newQuestion(t, &game,
 		NewCard("peacock"),
 		NewCard("dagger"),
 		NewCard("living room"),
@@ -504,19 +474,8 @@ func TestLinkResolutionWithoutFind(t *testing.T) {
 		t.Error("Charlie had either the bedroom or the dagger and we now know charlie has the dagger but the bedroom was incorrectly marked as found")
 	}
 
-	daggerLink := Link{
-		player: charlie,
-		other:  bedroomCard,
-	}
-	bedroomLink := Link{
-		player: charlie,
-		other:  daggerCard,
-	}
-	if slices.Contains(daggerCard.links, daggerLink) {
-		t.Error("The dagger's link has served it's purpose but it wasn't removed")
-	}
-	if slices.Contains(bedroomCard.links, bedroomLink) {
-		t.Error("The bedroom's link has served it's purpose but it wasn't removed")
+	if hasClause(game, charlie, daggerCard, bedroomCard) {
+		t.Error("The link between the dagger and the bedroom has served it's purpose but it wasn't removed")
 	}
 }
 
@@ -524,7 +483,7 @@ func TestTriLinkResolutionWithFind(t *testing.T) {
 	game, alice, bob, charlie := GenSampleGame()
 
 	//create trilink
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -535,7 +494,7 @@ func TestTriLinkResolutionWithFind(t *testing.T) {
 	game.DoTurn(question)
 
 	//shrink to link
-	question = NewQuestion(
+	question = newQuestion(t, &game,
 		NewCard("peacock"),
 		NewCard("dagger"),
 		NewCard("living room"),
@@ -550,49 +509,11 @@ func TestTriLinkResolutionWithFind(t *testing.T) {
 	daggerCard := lookupCard(t, game, "dagger")
 	bedroomCard := lookupCard(t, game, "bedroom")
 
-	greenLink := Link{
-		player: charlie,
-		other:  bedroomCard,
-	}
-	bedroomLink := Link{
-		player: charlie,
-		other:  greenCard,
-	}
-
-	if !slices.Contains(greenCard.links, greenLink) {
-		t.Error("Game.analyseUnknownAnswer() TriLink should have been resolved to a normal link but one wasn't created")
-	}
-	if !slices.Contains(bedroomCard.links, bedroomLink) {
+	if !hasClause(game, charlie, greenCard, bedroomCard) {
 		t.Error("Game.analyseUnknownAnswer() TriLink should have been resolved to a normal link but one wasn't created")
 	}
-
-	greenTriLink := TriLink{
-		this:   greenCard,
-		player: charlie,
-		other1: daggerCard,
-		other2: bedroomCard,
-	}
-	daggerTriLink := TriLink{
-		this:   daggerCard,
-		player: charlie,
-		other1: greenCard,
-		other2: bedroomCard,
-	}
-	bedroomTriLink := TriLink{
-		this:   bedroomCard,
-		player: charlie,
-		other1: daggerCard,
-		other2: greenCard,
-	}
-
-	if slices.ContainsFunc(greenCard.trilinks, func(t TriLink) bool { return greenTriLink.Equals(t) }) {
-		t.Error("The bedroom's trilink has served it's purpose but it wasn't removed")
-	}
-	if slices.ContainsFunc(daggerCard.trilinks, func(t TriLink) bool { return daggerTriLink.Equals(t) }) {
-		t.Error("The dagger's trilink has served it's purpose but it wasn't removed")
-	}
-	if slices.ContainsFunc(bedroomCard.trilinks, func(t TriLink) bool { return bedroomTriLink.Equals(t) }) {
-		t.Error("The bedroom's trilink has served it's purpose but it wasn't removed")
+	if hasClause(game, charlie, greenCard, daggerCard, bedroomCard) {
+		t.Error("The trilink has served it's purpose but it wasn't removed")
 	}
 }
 
@@ -600,7 +521,7 @@ func TestTriLinkResolutionWithoutFind(t *testing.T) {
 	game, _, bob, charlie := GenSampleGame()
 
 	//create trilink
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -611,7 +532,7 @@ func TestTriLinkResolutionWithoutFind(t *testing.T) {
 	game.DoTurn(question)
 
 	//shrink to link
-	question = NewQuestion(
+	question = newQuestion(t, &game,
 		NewCard("peacock"),
 		NewCard("dagger"),
 		NewCard("living room"),
@@ -626,56 +547,18 @@ func TestTriLinkResolutionWithoutFind(t *testing.T) {
 	daggerCard := lookupCard(t, game, "dagger")
 	bedroomCard := lookupCard(t, game, "bedroom")
 
-	greenLink := Link{
-		player: charlie,
-		other:  bedroomCard,
-	}
-	bedroomLink := Link{
-		player: charlie,
-		other:  greenCard,
-	}
-
-	if slices.Contains(greenCard.links, greenLink) {
+	if hasClause(game, charlie, greenCard, bedroomCard) {
 		t.Error("Game.analyseUnknownAnswer() TriLink was resolved to a normal link but shouldn't have been")
 	}
-	if slices.Contains(bedroomCard.links, bedroomLink) {
-		t.Error("Game.analyseUnknownAnswer() TriLink was resolved to a normal link but shouldn't have been")
-	}
-
-	greenTriLink := TriLink{
-		this:   greenCard,
-		player: charlie,
-		other1: daggerCard,
-		other2: bedroomCard,
-	}
-	daggerTriLink := TriLink{
-		this:   daggerCard,
-		player: charlie,
-		other1: greenCard,
-		other2: bedroomCard,
-	}
-	bedroomTriLink := TriLink{
-		this:   bedroomCard,
-		player: charlie,
-		other1: daggerCard,
-		other2: greenCard,
-	}
-
-	if slices.ContainsFunc(greenCard.trilinks, func(t TriLink) bool { return greenTriLink.Equals(t) }) {
-		t.Error("The bedroom's trilink has served it's purpose but it wasn't removed")
-	}
-	if slices.ContainsFunc(daggerCard.trilinks, func(t TriLink) bool { return daggerTriLink.Equals(t) }) {
-		t.Error("The dagger's trilink has served it's purpose but it wasn't removed")
-	}
-	if slices.ContainsFunc(bedroomCard.trilinks, func(t TriLink) bool { return bedroomTriLink.Equals(t) }) {
-		t.Error("The bedroom's trilink has served it's purpose but it wasn't removed")
+	if hasClause(game, charlie, greenCard, daggerCard, bedroomCard) {
+		t.Error("The trilink has served it's purpose but it wasn't removed")
 	}
 }
 
 func TestCompletePlayer(t *testing.T) {
 	game, alice, _, _ := GenSampleGame()
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -685,7 +568,7 @@ func TestCompletePlayer(t *testing.T) {
 	question.SetAnswer(WhoAnswer)
 	game.DoTurn(question)
 
-	question = NewQuestion(
+	question = newQuestion(t, &game,
 		NewCard("scarlet"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -695,7 +578,7 @@ func TestCompletePlayer(t *testing.T) {
 	question.SetAnswer(WhoAnswer)
 	game.DoTurn(question)
 
-	question = NewQuestion(
+	question = newQuestion(t, &game,
 		NewCard("peacock"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -705,7 +588,7 @@ func TestCompletePlayer(t *testing.T) {
 	question.SetAnswer(WhoAnswer)
 	game.DoTurn(question)
 
-	question = NewQuestion(
+	question = newQuestion(t, &game,
 		NewCard("mustard"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -716,7 +599,7 @@ func TestCompletePlayer(t *testing.T) {
 	game.DoTurn(question)
 
 	candlestickCard := lookupCard(t, game, "candlestick")
-	if !slices.Contains(candlestickCard.nonPossessors, alice) {
+	if !candlestickCard.isNonPossessor(alice) {
 		t.Error("I know all of Alice's cards but other cards aren't marked as not hers")
 	}
 }
@@ -736,7 +619,7 @@ func TestAllNonPossessorsFound(t *testing.T) {
 	game.Update()
 
 	pistolCard := lookupCard(t, game, "pistol")
-	if !pistolCard.IsFound() || pistolCard.possessor != alice {
+	if !pistolCard.IsFound() || pistolCard.Possessor() != alice {
 		t.Error("We know alice has 4 cards and we know she doesn't have all the cards except for 4. The pistol wasn't marked as found")
 	}
 }
@@ -753,13 +636,13 @@ func TestAllNonPossessorsFoundWithKnowns(t *testing.T) {
 	lookupCard(t, game, "wrench").AddNonPossessor(alice)
 	lookupCard(t, game, "dagger").AddNonPossessor(alice)
 
-	lookupCard(t, game, "candlestick").SetFound(alice, true)
-	lookupCard(t, game, "rope").SetFound(alice, true)
+	lookupCard(t, game, "candlestick").SetFound(alice)
+	lookupCard(t, game, "rope").SetFound(alice)
 
 	game.Update()
 
 	pistolCard := lookupCard(t, game, "pistol")
-	if !pistolCard.IsFound() || pistolCard.possessor != alice {
+	if !pistolCard.IsFound() || pistolCard.Possessor() != alice {
 		t.Error("We know alice has 4 cards and we know she doesn't have all the cards except for 4 and has 2 cards. The pistol wasn't marked as found.")
 	}
 }
@@ -771,7 +654,7 @@ func TestCategoryCompleted(t *testing.T) {
 		NewCard("scarlet"),
 	})
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -781,7 +664,7 @@ func TestCategoryCompleted(t *testing.T) {
 	question.SetAnswer(WhoAnswer)
 	game.DoTurn(question)
 
-	question = NewQuestion(
+	question = newQuestion(t, &game,
 		NewCard("mustard"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -791,7 +674,7 @@ func TestCategoryCompleted(t *testing.T) {
 	question.SetAnswer(WhoAnswer)
 	game.DoTurn(question)
 
-	question = NewQuestion(
+	question = newQuestion(t, &game,
 		NewCard("peacock"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -801,7 +684,7 @@ func TestCategoryCompleted(t *testing.T) {
 	question.SetAnswer(WhoAnswer)
 	game.DoTurn(question)
 
-	if !lookupCard(t, game, "white").isMurderItem {
+	if !lookupCard(t, game, "white").IsMurderItem() {
 		t.Error("Found all cards in category except White but White wasn't marked as the murderer")
 	}
 }
@@ -810,7 +693,7 @@ func TestCardCompleteNonPosessors(t *testing.T) {
 	game, alice, bob, charlie := GenSampleGame()
 	game.AddStartingHand([]*Card{})
 
-	question := NewQuestion(
+	question := newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("dagger"),
 		NewCard("bedroom"),
@@ -820,7 +703,7 @@ func TestCardCompleteNonPosessors(t *testing.T) {
 	question.SetAnswer(NoAnswer)
 	game.DoTurn(question)
 
-	question = NewQuestion(
+	question = newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("rope"),
 		NewCard("garage"),
@@ -830,7 +713,7 @@ func TestCardCompleteNonPosessors(t *testing.T) {
 	question.SetAnswer(NoAnswer)
 	game.DoTurn(question)
 
-	question = NewQuestion(
+	question = newQuestion(t, &game,
 		NewCard("green"),
 		NewCard("pistol"),
 		NewCard("kitchen"),
@@ -840,7 +723,328 @@ func TestCardCompleteNonPosessors(t *testing.T) {
 	question.SetAnswer(NoAnswer)
 	game.DoTurn(question)
 
-	if !lookupCard(t, game, "green").isMurderItem {
+	if !lookupCard(t, game, "green").IsMurderItem() {
 		t.Error("No one has Green in their hands but Green wasn't marked as the murderer")
 	}
 }
+
+func TestTurnResultShownCard(t *testing.T) {
+	game, alice, _, _ := GenSampleGame()
+
+	question := newQuestion(t, &game,
+		NewCard("white"),
+		NewCard("pistol"),
+		NewCard("bedroom"),
+		game.Me,
+		alice,
+	)
+	question.SetAnswer(WhatAnswer)
+	result, err := game.DoTurn(question)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := result.Found()
+	if len(found) != 1 {
+		t.Fatalf("Game.DoTurn() Alice showed 1 card but %d cards were reported as found", len(found))
+	}
+	if found[0].Player != "alice" || found[0].Cards[0] != "pistol" || found[0].Via != nil {
+		t.Errorf("Game.DoTurn() Alice showed the pistol but the new fact was `%v`", found[0])
+	}
+}
+
+func TestTurnResultViaLink(t *testing.T) {
+	game, alice, bob, charlie := GenSampleGame()
+	game.AddStartingHand([]*Card{
+		NewCard("green"),
+	})
+
+	question := newQuestion(t, &game,
+		NewCard("green"),
+		NewCard("dagger"),
+		NewCard("bedroom"),
+		bob,
+		charlie,
+	)
+	question.SetAnswer(UnknownAnswer)
+	result, err := game.DoTurn(question)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.ContainsFunc(result.Facts, func(f Fact) bool { return f.Kind == FactConstraintAdded && f.Player == "charlie" }) {
+		t.Error("Game.DoTurn() A link was made for charlie but it wasn't in the turn result")
+	}
+
+	question = newQuestion(t, &game,
+		NewCard("peacock"),
+		NewCard("dagger"),
+		NewCard("living room"),
+		game.Me,
+		alice,
+	)
+	question.SetAnswer(WhatAnswer)
+	result, err = game.DoTurn(question)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "New: charlie has bedroom (via link from turn 1)"
+	if !slices.ContainsFunc(result.Facts, func(f Fact) bool { return "New: "+f.String() == want }) {
+		t.Errorf("Game.DoTurn() Expected `%s` in the turn result but got\n%v", want, result)
+	}
+	if !slices.ContainsFunc(result.Facts, func(f Fact) bool { return f.Kind == FactConstraintResolved && f.Player == "charlie" }) {
+		t.Error("Game.DoTurn() Charlie's link was resolved but it wasn't in the turn result")
+	}
+}
+
+func TestClauseResolvesWhenOthersEliminated(t *testing.T) {
+	game, alice, _, _ := GenSampleGame()
+
+	game.AddClause(alice,
+		lookupCard(t, game, "green"),
+		lookupCard(t, game, "rope"),
+		lookupCard(t, game, "study"),
+		lookupCard(t, game, "kitchen"),
+	)
+	game.Update()
+
+	if len(game.ClausesFor(alice)) != 1 {
+		t.Fatal("Game.AddClause() Alice's clause over 4 cards wasn't kept")
+	}
+
+	lookupCard(t, game, "green").AddNonPossessor(alice)
+	lookupCard(t, game, "rope").AddNonPossessor(alice)
+	game.Update()
+
+	if !hasClause(game, alice, lookupCard(t, game, "study"), lookupCard(t, game, "kitchen")) {
+		t.Error("Game.UpdateClauses() Alice didn't have green or rope but her clause wasn't shrunk to study and kitchen")
+	}
+
+	lookupCard(t, game, "kitchen").AddNonPossessor(alice)
+	game.Update()
+
+	studyCard := lookupCard(t, game, "study")
+	if studyCard.Possessor() != alice {
+		t.Error("Game.UpdateClauses() Study was the only card left in alice's clause but it wasn't marked as hers")
+	}
+	if len(game.Clauses()) != 0 {
+		t.Error("Game.UpdateClauses() Alice's clause was resolved but it wasn't removed")
+	}
+}
+
+func TestClauseSatisfiedByFind(t *testing.T) {
+	game, alice, _, _ := GenSampleGame()
+
+	game.AddClause(alice,
+		lookupCard(t, game, "green"),
+		lookupCard(t, game, "rope"),
+		lookupCard(t, game, "study"),
+		lookupCard(t, game, "kitchen"),
+	)
+	lookupCard(t, game, "rope").SetFound(alice)
+	game.Update()
+
+	if len(game.Clauses()) != 0 {
+		t.Error("Game.UpdateClauses() Alice was found to have rope but her clause containing it wasn't removed")
+	}
+	if lookupCard(t, game, "study").IsFound() {
+		t.Error("Game.UpdateClauses() Alice's clause was satisfied by rope but study was marked as found")
+	}
+}
+
+func TestClauseSkipsMurderItem(t *testing.T) {
+	game, alice, bob, charlie := GenSampleGame()
+	game.AddStartingHand([]*Card{})
+
+	game.AddClause(alice, lookupCard(t, game, "green"), lookupCard(t, game, "rope"))
+
+	for _, p := range []*Player{alice, bob, charlie} {
+		lookupCard(t, game, "green").AddNonPossessor(p)
+	}
+	game.Update()
+
+	if lookupCard(t, game, "rope").Possessor() != alice {
+		t.Error("Game.UpdateClauses() Green is a murder element so alice should have had rope")
+	}
+}
+
+func TestClauseSupersetIsRedundant(t *testing.T) {
+	game, alice, _, _ := GenSampleGame()
+
+	green := lookupCard(t, game, "green")
+	rope := lookupCard(t, game, "rope")
+	study := lookupCard(t, game, "study")
+
+	game.AddClause(alice, green, rope, study)
+	game.AddClause(alice, green, rope)
+	game.Update()
+
+	if hasClause(game, alice, green, rope, study) || !hasClause(game, alice, green, rope) {
+		t.Error("Game.UpdateClauses() Alice's trilink is implied by her link but both were kept")
+	}
+}
+
+func TestClauseCardinality(t *testing.T) {
+	bob := NewPlayer("bob", 4)
+	game := NewDefaultGame(NewPlayer("alice", 4), bob, NewPlayer("charlie", 4))
+
+	lookupCard(t, game, "green").SetFound(bob)
+	lookupCard(t, game, "rope").SetFound(bob)
+	game.AddClause(bob, lookupCard(t, game, "plum"), lookupCard(t, game, "study"))
+	game.AddClause(bob, lookupCard(t, game, "dagger"), lookupCard(t, game, "kitchen"))
+	game.Update()
+
+	for _, name := range []string{"white", "pistol", "garage", "courtyard"} {
+		if !lookupCard(t, game, name).isNonPossessor(bob) {
+			t.Errorf("Game.UpdateClauseCardinality() Bob's last 2 cards are in 2 separate links but %s wasn't ruled out", name)
+		}
+	}
+	for _, name := range []string{"plum", "study", "dagger", "kitchen"} {
+		if lookupCard(t, game, name).isNonPossessor(bob) {
+			t.Errorf("Game.UpdateClauseCardinality() %s is in one of bob's links but was ruled out", name)
+		}
+	}
+}
+
+func TestClauseCardinalityOverlapping(t *testing.T) {
+	bob := NewPlayer("bob", 2)
+	game := NewDefaultGame(NewPlayer("alice", 4), bob)
+
+	game.AddClause(bob, lookupCard(t, game, "plum"), lookupCard(t, game, "study"))
+	game.AddClause(bob, lookupCard(t, game, "plum"), lookupCard(t, game, "kitchen"))
+	game.Update()
+
+	if lookupCard(t, game, "white").isNonPossessor(bob) {
+		t.Error("Game.UpdateClauseCardinality() Bob's links share plum so he could still have another card but white was ruled out")
+	}
+}
+
+func TestEnvelopeOwnsOnePerCategory(t *testing.T) {
+	game, alice, bob, charlie := GenSampleGame()
+	game.AddStartingHand([]*Card{})
+
+	for _, p := range []*Player{alice, bob, charlie} {
+		lookupCard(t, game, "white").AddNonPossessor(p)
+	}
+	lookupCard(t, game, "green").AddNonPossessor(alice)
+	lookupCard(t, game, "green").AddNonPossessor(bob)
+	game.Update()
+
+	if !lookupCard(t, game, "white").IsMurderItem() {
+		t.Fatal("No one could have White but White wasn't marked as the murderer")
+	}
+	if !lookupCard(t, game, "plum").inEnvelopeRuledOut() {
+		t.Error("Game.UpdateEnvelope() White is in the envelope but Plum wasn't ruled out of it")
+	}
+	if lookupCard(t, game, "green").Possessor() != charlie {
+		t.Error("Game.UpdateEnvelope() Green isn't in the envelope and only charlie could have it but it wasn't marked as his")
+	}
+}
+
+func TestEnvelopeLastCandidate(t *testing.T) {
+	game, alice, _, _ := GenSampleGame()
+
+	for _, c := range game.whatCategory.Cards {
+		if c.name != "rope" {
+			c.AddNonPossessor(game.Envelope)
+		}
+	}
+	lookupCard(t, game, "rope").AddNonPossessor(alice)
+	game.Update()
+
+	if !lookupCard(t, game, "rope").IsMurderItem() {
+		t.Error("Game.UpdateEnvelope() Every other weapon was ruled out of the envelope but rope wasn't marked as the murder weapon")
+	}
+}
+
+func TestUnknownAnswerAnswererHasOne(t *testing.T) {
+	game, _, bob, charlie := GenSampleGame()
+
+	question := newQuestion(t, &game, NewCard("plum"), NewCard("dagger"), NewCard("study"), game.Me, charlie)
+	question.SetAnswer(WhatAnswer)
+	game.DoTurn(question)
+
+	question = newQuestion(t, &game, NewCard("green"), NewCard("dagger"), NewCard("bedroom"), bob, charlie)
+	question.SetAnswer(UnknownAnswer)
+	game.DoTurn(question)
+
+	if len(game.Clauses()) != 0 {
+		t.Errorf("Game.analyseUnknownAnswer() charlie already had the dagger so nothing new was learnt but there were %d clauses", len(game.Clauses()))
+	}
+	if lookupCard(t, game, "green").IsFound() || lookupCard(t, game, "bedroom").IsFound() {
+		t.Error("Game.analyseUnknownAnswer() charlie already had the dagger but another card was assumed to be shown.")
+	}
+	if shows := game.ShownTo(bob); len(shows) != 1 || len(shows[0].Cards()) != 3 {
+		t.Errorf("Game.ShownTo() bob could've seen any of the 3 cards but the shows were %v", shows)
+	}
+}
+
+func TestUnknownAnswerAnswererRuledOut(t *testing.T) {
+	game, alice, bob, charlie := GenSampleGame()
+
+	question := newQuestion(t, &game, NewCard("green"), NewCard("dagger"), NewCard("study"), alice, charlie)
+	question.SetAnswer(NoAnswer)
+	game.DoTurn(question)
+
+	question = newQuestion(t, &game, NewCard("green"), NewCard("dagger"), NewCard("bedroom"), bob, charlie)
+	question.SetAnswer(UnknownAnswer)
+	game.DoTurn(question)
+
+	bedroomCard := lookupCard(t, game, "bedroom")
+	if bedroomCard.Possessor() != charlie {
+		t.Error("Game.analyseUnknownAnswer() charlie didn't have green or dagger and showed a card but bedroom wasn't marked as his.")
+	}
+	if !slices.Contains(game.SeenBy(bob), bedroomCard) {
+		t.Error("Game.SeenBy() charlie could only have shown bob the bedroom but bob wasn't marked as having seen it.")
+	}
+	if len(game.ShownTo(bob)) != 0 {
+		t.Error("Game.ShownTo() bob's show was worked out but it was still listed.")
+	}
+}
+
+func TestUnknownAnswerImpossible(t *testing.T) {
+	game, alice, bob, charlie := GenSampleGame()
+
+	question := newQuestion(t, &game, NewCard("green"), NewCard("dagger"), NewCard("bedroom"), alice, charlie)
+	question.SetAnswer(NoAnswer)
+	game.DoTurn(question)
+
+	question = newQuestion(t, &game, NewCard("green"), NewCard("dagger"), NewCard("bedroom"), bob, charlie)
+	question.SetAnswer(UnknownAnswer)
+	game.DoTurn(question)
+
+	if len(game.Clauses()) != 0 || len(game.ShownTo(bob)) != 0 {
+		t.Error("Game.analyseUnknownAnswer() charlie couldn't have shown anything but the show was kept.")
+	}
+	if lookupCard(t, game, "green").IsFound() || lookupCard(t, game, "dagger").IsFound() || lookupCard(t, game, "bedroom").IsFound() {
+		t.Error("Game.analyseUnknownAnswer() charlie couldn't have shown anything but a card was marked as found.")
+	}
+}
+
+func TestShownByMe(t *testing.T) {
+	game, _, bob, _ := GenSampleGame()
+	game.AddStartingHand([]*Card{
+		NewCard("green"),
+		NewCard("rope"),
+	})
+
+	question := newQuestion(t, &game, NewCard("green"), NewCard("dagger"), NewCard("bedroom"), bob, game.Me)
+	question.SetAnswer(UnknownAnswer)
+	game.DoTurn(question)
+
+	if !slices.Contains(game.SeenBy(bob), lookupCard(t, game, "green")) {
+		t.Error("Game.SeenBy() green was the only card we could show bob but he wasn't marked as having seen it.")
+	}
+
+	question = newQuestion(t, &game, NewCard("plum"), NewCard("rope"), NewCard("study"), bob, game.Me)
+	question.SetAnswer(WhatAnswer)
+	game.DoTurn(question)
+
+	if !slices.Contains(game.SeenBy(bob), lookupCard(t, game, "rope")) {
+		t.Error("Game.SeenBy() we showed bob the rope but he wasn't marked as having seen it.")
+	}
+	if len(game.Clauses()) != 0 {
+		t.Error("Game.DoTurn() showing our own card created a clause.")
+	}
+}
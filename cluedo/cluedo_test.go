@@ -1,6 +1,11 @@
 package cluedo_test
 
 import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
@@ -18,9 +23,9 @@ func GenSampleCardCategory() cluedo.CardCategory {
 func TestHasKnownSolutionWithSolution(t *testing.T) {
 	q := GenSampleCardCategory()
 
-	q.Cards[0].SetFound(nil, true)
-	q.Cards[1].SetFound(nil, true)
-	q.Cards[2].SetFound(nil, true)
+	q.Cards[0].SetFound(nil)
+	q.Cards[1].SetFound(nil)
+	q.Cards[2].SetFound(nil)
 
 	if !q.HasKnownSolution() {
 		t.Error("Question.HasKnownSolution() Couldn't find solution when there was one present.")
@@ -30,8 +35,8 @@ func TestHasKnownSolutionWithSolution(t *testing.T) {
 func TestHasKnownSolutionWithoutSolution(t *testing.T) {
 	q := GenSampleCardCategory()
 
-	q.Cards[0].SetFound(nil, true)
-	q.Cards[1].SetFound(nil, true)
+	q.Cards[0].SetFound(nil)
+	q.Cards[1].SetFound(nil)
 
 	if q.HasKnownSolution() {
 		t.Error("Question.HasKnownSolution() Found a solution when there were multiple options.")
@@ -41,10 +46,10 @@ func TestHasKnownSolutionWithoutSolution(t *testing.T) {
 func TestHasKnownSolutionWithoutOptions(t *testing.T) {
 	q := GenSampleCardCategory()
 
-	q.Cards[0].SetFound(nil, true)
-	q.Cards[1].SetFound(nil, true)
-	q.Cards[2].SetFound(nil, true)
-	q.Cards[3].SetFound(nil, true)
+	q.Cards[0].SetFound(nil)
+	q.Cards[1].SetFound(nil)
+	q.Cards[2].SetFound(nil)
+	q.Cards[3].SetFound(nil)
 
 	if q.HasKnownSolution() {
 		t.Error("Question.HasKnownSolution() Found a solution when there were no options left.")
@@ -60,10 +65,32 @@ func GenSampleGame() (game cluedo.Game, a, b, c *cluedo.Player) {
 	return
 }
 
+// newQuestion makes a question in g from cards and players that may not be
+// g's own.
+func newQuestion(t testing.TB, g *cluedo.Game, who, what, where *cluedo.Card, asker, answerer *cluedo.Player) cluedo.Question {
+	t.Helper()
+	q, err := g.QuestionFor(who, what, where, asker, answerer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+func TestStandaloneCard(t *testing.T) {
+	alice := cluedo.NewPlayer("alice", 4)
+	card := cluedo.NewCard("rope")
+
+	card.SetFound(alice)
+	card.AddNonPossessor(alice)
+	if card.IsFound() || card.Possessor() != nil || card.IsMurderItem() || len(card.NonPossessors()) != 0 {
+		t.Error("Card A card outside of a game shouldn't know anything about itself")
+	}
+}
+
 func TestEnsureValidQuestionWithValid(t *testing.T) {
 	game, alice, _, _ := GenSampleGame()
 
-	question := cluedo.NewQuestion(
+	question := newQuestion(t, &game,
 		cluedo.NewCard("plum"),
 		cluedo.NewCard("dagger"),
 		cluedo.NewCard("study"),
@@ -79,7 +106,44 @@ func TestEnsureValidQuestionWithValid(t *testing.T) {
 func TestEnsureValidQuestionWithInvalidQuestionComponents(t *testing.T) {
 	game, alice, _, _ := GenSampleGame()
 
-	question := cluedo.NewQuestion(
+	me, _ := game.LookupPlayer(cluedo.MeIdent)
+	a, _ := game.LookupPlayer(alice.Name())
+	question := game.NewQuestion(
+		cluedo.CardID(40),
+		cluedo.CardID(41),
+		cluedo.CardID(42),
+		me,
+		a,
+	)
+
+	if game.EnsureValidQuestion(question) {
+		t.Error("Game.EnsureValidQuestion() Question was deemed valid when it wasn't made of elements from the game")
+	}
+}
+
+func TestEnsureValidQuestionWithInvalidPlayers(t *testing.T) {
+	game, _, _, _ := GenSampleGame()
+
+	mustard, _ := game.LookupCard("mustard")
+	rope, _ := game.LookupCard("rope")
+	kitchen, _ := game.LookupCard("kitchen")
+	question := game.NewQuestion(
+		mustard,
+		rope,
+		kitchen,
+		cluedo.PlayerID(7),
+		cluedo.PlayerID(8),
+	)
+
+	if game.EnsureValidQuestion(question) {
+		t.Error("Game.EnsureValidQuestion() Question was deemed valid when the players weren't ones in the game")
+	}
+}
+
+func TestQuestionForWithInvalidQuestionComponents(t *testing.T) {
+	game, alice, _, _ := GenSampleGame()
+
+	_, err := game.QuestionFor(
 		cluedo.NewCard("eva smith"),
 		cluedo.NewCard("bleach"),
 		cluedo.NewCard("infermary"),
@@ -87,15 +151,15 @@ func TestEnsureValidQuestionWithInvalidQuestionComponents(t *testing.T) {
 		alice,
 	)
 
-	if game.EnsureValidQuestion(question) {
-		t.Error("Game.EnsureValidQuestion() Question was deemed valid when it wasn't made of elements from the game")
+	if err == nil {
+		t.Error("Game.QuestionFor() Question was made when it wasn't made of elements from the game")
 	}
 }
 
-func TestEnsureValidQuestionWithInvalidPlayers(t *testing.T) {
+func TestQuestionForWithInvalidPlayers(t *testing.T) {
 	game, _, _, _ := GenSampleGame()
 
-	question := cluedo.NewQuestion(
+	_, err := game.QuestionFor(
 		cluedo.NewCard("mustard"),
 		cluedo.NewCard("rope"),
 		cluedo.NewCard("kitchen"),
@@ -103,7 +167,527 @@ func TestEnsureValidQuestionWithInvalidPlayers(t *testing.T) {
 		cluedo.NewPlayer("inspector goole", 0),
 	)
 
-	if game.EnsureValidQuestion(question) {
-		t.Error("Game.EnsureValidQuestion() Question was deemed valid when the players weren't ones in the game")
+	if err == nil {
+		t.Error("Game.QuestionFor() Question was made when the players weren't ones in the game")
+	}
+}
+
+func TestInvalidTurnRejected(t *testing.T) {
+	game, alice, _, _ := GenSampleGame()
+
+	plum, _ := game.LookupCard("plum")
+	dagger, _ := game.LookupCard("dagger")
+	study, _ := game.LookupCard("study")
+	me, _ := game.LookupPlayer(cluedo.MeIdent)
+	a, _ := game.LookupPlayer(alice.Name())
+
+	// the what and where are the wrong way round
+	question := game.NewQuestion(plum, study, dagger, me, a)
+	if _, err := game.DoTurn(question); err == nil {
+		t.Error("Game.DoTurn() Played a question with the cards in the wrong categories")
+	}
+
+	question = game.NewQuestion(plum, dagger, study, me, a)
+	question.SetAnswer(cluedo.NoAnswer)
+	result, err := game.DoTurn(question)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Turn != 1 {
+		t.Errorf("Game.DoTurn() The first valid turn was numbered %d after an invalid one", result.Turn)
+	}
+}
+
+func TestSnapshotFoundCard(t *testing.T) {
+	game, alice, _, _ := GenSampleGame()
+
+	question := newQuestion(t, &game,
+		cluedo.NewCard("plum"),
+		cluedo.NewCard("dagger"),
+		cluedo.NewCard("study"),
+		game.Me,
+		alice,
+	)
+	question.SetAnswer(cluedo.WhatAnswer)
+	game.DoTurn(question)
+
+	card, ok := game.Snapshot().Card("dagger")
+	if !ok {
+		t.Fatal("Game.Snapshot() Dagger wasn't in the snapshot")
+	}
+	if card.Status != cluedo.StatusFound || card.Owner != "alice" {
+		t.Errorf("Game.Snapshot() Alice showed the dagger but the snapshot had status %q and owner %q", card.Status, card.Owner)
+	}
+}
+
+func TestSnapshotUnchangedByLaterTurns(t *testing.T) {
+	game, alice, _, _ := GenSampleGame()
+
+	before, err := game.Snapshot().JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := game.Snapshot()
+
+	question := newQuestion(t, &game,
+		cluedo.NewCard("plum"),
+		cluedo.NewCard("dagger"),
+		cluedo.NewCard("study"),
+		game.Me,
+		alice,
+	)
+	question.SetAnswer(cluedo.NoAnswer)
+	game.DoTurn(question)
+
+	after, err := snapshot.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(before) != string(after) {
+		t.Error("Game.Snapshot() The snapshot changed when a later turn was played")
+	}
+}
+
+func TestSnapshotConstraintsDeduplicated(t *testing.T) {
+	bob := cluedo.NewPlayer("bob", 6)
+	charlie := cluedo.NewPlayer("charlie", 6)
+	game := cluedo.NewDefaultGame(bob, charlie)
+
+	question := newQuestion(t, &game,
+		cluedo.NewCard("plum"),
+		cluedo.NewCard("dagger"),
+		cluedo.NewCard("study"),
+		bob,
+		charlie,
+	)
+	question.SetAnswer(cluedo.UnknownAnswer)
+	game.DoTurn(question)
+
+	constraints := game.Snapshot().Constraints
+	if len(constraints) != 1 {
+		t.Fatalf("Game.Snapshot() Expected 1 constraint from the trilink but got %d", len(constraints))
+	}
+	if constraints[0].Player != "charlie" || len(constraints[0].Cards) != 3 {
+		t.Errorf("Game.Snapshot() Constraint was %v but should've been charlie with 3 cards", constraints[0])
+	}
+}
+
+func TestTranscriptReplay(t *testing.T) {
+	transcript, err := cluedo.ParseTranscript(strings.NewReader(`
+players alice=5 bob=5 charlie=4
+hand green, wrench, candlestick, study
+
+# bob asks charlie
+suggest bob: green, dagger, bedroom | show charlie
+suggest ME: peacock, dagger, living room | show alice dagger
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := transcript.Replay(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	card, _ := game.Snapshot().Card("bedroom")
+	if card.Owner != "charlie" {
+		t.Errorf("Transcript.Replay() Charlie should have had the bedroom but the owner was %q", card.Owner)
+	}
+}
+
+func TestTranscriptAccusation(t *testing.T) {
+	transcript, err := cluedo.ParseTranscript(strings.NewReader(`players alice=9 bob=9
+accuse alice: plum, rope, kitchen
+accuse bob: rope, plum, kitchen`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transcript.Lines[1].Kind != cluedo.AccuseLine || transcript.Lines[1].Asker != "alice" {
+		t.Errorf("ParseTranscript() Read the accusation as %+v", transcript.Lines[1])
+	}
+
+	_, err = transcript.Replay(nil)
+
+	var lineErr cluedo.TranscriptError
+	if !errors.As(err, &lineErr) || lineErr.Line != 3 {
+		t.Errorf("Transcript.Replay() Expected the accusation with the cards in the wrong order to fail on line 3 but got %v", err)
+	}
+}
+
+func TestTranscriptDealMustAddUp(t *testing.T) {
+	for _, text := range []string{
+		"players alice=4 bob=4 charlie=4\nhand green\n",
+		"players alice=4 bob=4 charlie=4\nsuggest bob: green, dagger, bedroom | show charlie\n",
+	} {
+		transcript, err := cluedo.ParseTranscript(strings.NewReader(text))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var lineErr cluedo.TranscriptError
+		if _, err := transcript.Replay(nil); !errors.As(err, &lineErr) || lineErr.Line != 2 {
+			t.Errorf("Transcript.Replay() Expected 13 dealt cards to fail on line 2 but got %v", err)
+		}
+	}
+}
+
+func TestTranscriptPassContradictsOwner(t *testing.T) {
+	transcript, err := cluedo.ParseTranscript(strings.NewReader(`players alice=5 bob=5 charlie=4
+hand peacock, white, rope, bathroom
+suggest ME: white, wrench, dining room | show alice dining room
+suggest ME: plum, wrench, dining room | pass alice`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var lineErr cluedo.TranscriptError
+	if _, err := transcript.Replay(nil); !errors.As(err, &lineErr) || lineErr.Line != 4 {
+		t.Errorf("Transcript.Replay() Expected alice passing on her own dining room to fail on line 4 but got %v", err)
+	}
+}
+
+func TestTranscriptSuggestionChecked(t *testing.T) {
+	for _, test := range []struct {
+		line string
+		want string
+	}{
+		{"suggest alice: kitchen, plum, rope", "`kitchen` isn't a who card"},
+		{"suggest alice: kitchen, plum, rope | pass bob", "`kitchen` isn't a who card"},
+		{"suggest alice: plum, rope, kitchen", "suggestion needs someone to pass or show"},
+	} {
+		transcript, err := cluedo.ParseTranscript(strings.NewReader("players alice=9 bob=9\n" + test.line))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := transcript.Replay(nil); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("Transcript.Replay() Expected %q to fail with %q but got %v", test.line, test.want, err)
+		}
+	}
+}
+
+func TestTranscriptInvalidLineNumbers(t *testing.T) {
+	_, err := cluedo.ParseTranscript(strings.NewReader("players alice=9 bob=9\n\nsuggest ME: green dagger bedroom\n"))
+
+	var lineErr cluedo.TranscriptError
+	if !errors.As(err, &lineErr) {
+		t.Fatalf("ParseTranscript() Expected a TranscriptError but got %v", err)
+	}
+	if lineErr.Line != 3 {
+		t.Errorf("ParseTranscript() The bad suggestion was on line 3 but the error said line %d", lineErr.Line)
+	}
+}
+
+func TestTranscriptReplayUnknownCard(t *testing.T) {
+	transcript, err := cluedo.ParseTranscript(strings.NewReader(`players alice=9 bob=9
+suggest ME: green, dagger, bedroom | pass alice
+suggest ME: green, spoon, bedroom | show bob`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = transcript.Replay(nil)
+
+	var lineErr cluedo.TranscriptError
+	if !errors.As(err, &lineErr) || lineErr.Line != 3 {
+		t.Errorf("Transcript.Replay() Expected an error on line 3 for the unknown card but got %v", err)
+	}
+}
+
+func TestWhatIfLeavesGameUnchanged(t *testing.T) {
+	bob := cluedo.NewPlayer("bob", 4)
+	charlie := cluedo.NewPlayer("charlie", 4)
+	alice := cluedo.NewPlayer("alice", 4)
+	game := cluedo.NewDefaultGame(alice, bob, charlie)
+	game.AddStartingHand([]*cluedo.Card{cluedo.NewCard("green")})
+
+	question := newQuestion(t, &game,
+		cluedo.NewCard("green"),
+		cluedo.NewCard("dagger"),
+		cluedo.NewCard("bedroom"),
+		bob,
+		charlie,
+	)
+	question.SetAnswer(cluedo.UnknownAnswer)
+	game.DoTurn(question)
+
+	before, _ := game.Snapshot().JSON()
+
+	question = newQuestion(t, &game,
+		cluedo.NewCard("peacock"),
+		cluedo.NewCard("dagger"),
+		cluedo.NewCard("living room"),
+		game.Me,
+		alice,
+	)
+	result, err := game.WhatIf(question, cluedo.WhatAnswer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after, _ := game.Snapshot().JSON()
+	if string(before) != string(after) {
+		t.Error("Game.WhatIf() The real game was changed")
+	}
+
+	found := []string{}
+	for _, f := range result.Found() {
+		found = append(found, f.Player+" "+f.Cards[0])
+	}
+	if !slices.Contains(found, "alice dagger") || !slices.Contains(found, "charlie bedroom") {
+		t.Errorf("Game.WhatIf() Alice showing the dagger should've found the dagger and charlie's bedroom but found %v", found)
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	game, alice, _, _ := GenSampleGame()
+	clone := game.Clone()
+
+	question := newQuestion(t, &game,
+		cluedo.NewCard("plum"),
+		cluedo.NewCard("dagger"),
+		cluedo.NewCard("study"),
+		game.Me,
+		alice,
+	)
+	question.SetAnswer(cluedo.WhatAnswer)
+	game.DoTurn(question)
+
+	if card, _ := clone.Snapshot().Card("dagger"); card.Status != cluedo.StatusUnknown {
+		t.Error("Game.Clone() A turn on the original game changed the clone")
+	}
+}
+
+func TestDuplicatePlayerNames(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewDefaultGame() Two different players called alice were allowed.")
+		}
+	}()
+
+	cluedo.NewDefaultGame(cluedo.NewPlayer("alice", 4), cluedo.NewPlayer("alice", 4))
+}
+
+func TestQuestionByID(t *testing.T) {
+	alice := cluedo.NewPlayer("alice", 4)
+	game := cluedo.NewDefaultGame(alice)
+
+	who, _ := game.LookupCard("green")
+	what, _ := game.LookupCard("dagger")
+	where, _ := game.LookupCard("study")
+	me, _ := game.LookupPlayer(cluedo.MeIdent)
+	answerer, err := game.LookupPlayer("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.PlayerByID(answerer) != alice {
+		t.Error("Game.LookupPlayer() alice's ID didn't lead back to alice.")
+	}
+
+	q := game.NewQuestion(who, what, where, me, answerer)
+	q.SetAnswer(cluedo.WhatAnswer)
+	game.DoTurn(q)
+
+	// the question's card and the one looked up by name are the same card
+	if game.CardByID(what) != game.Card("dagger") || game.Card("dagger").Possessor() != alice {
+		t.Error("Game.NewQuestion() dagger wasn't marked as alice's.")
+	}
+
+	if _, err := game.LookupCard("banana"); err == nil {
+		t.Error("Game.LookupCard() Found a card that isn't in the game.")
+	}
+}
+
+func TestSuggestionMovesTokens(t *testing.T) {
+	game, alice, bob, _ := GenSampleGame()
+
+	question := newQuestion(t, &game,
+		cluedo.NewCard("plum"),
+		cluedo.NewCard("dagger"),
+		cluedo.NewCard("garage"),
+		bob,
+		alice,
+	)
+	question.SetAnswer(cluedo.NoAnswer)
+	game.DoTurn(question)
+
+	if room, ok := game.TokenRoom("plum"); !ok || room != "garage" {
+		t.Errorf("Game.TokenRoom() plum was suggested in the garage but was in `%s`", room)
+	}
+	if room, ok := game.PlayerRoom(bob); !ok || room != "garage" {
+		t.Errorf("Game.PlayerRoom() bob suggested in the garage but was in `%s`", room)
+	}
+	if _, ok := game.TokenRoom("green"); ok {
+		t.Error("Game.TokenRoom() green hasn't been moved but had a room.")
+	}
+
+	if err := game.MoveToken("plum", "study"); err != nil {
+		t.Fatal(err)
+	}
+	if room, _ := game.TokenRoom("plum"); room != "study" {
+		t.Errorf("Game.MoveToken() plum was moved to the study but was in `%s`", room)
+	}
+	if err := game.MoveToken("garage", "plum"); err == nil {
+		t.Error("Game.MoveToken() Moved a room into a suspect.")
+	}
+
+	rooms, err := game.LikelyRooms(bob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rooms[0].Room != "kitchen" || rooms[0].Chance != 1 {
+		t.Errorf("Game.LikelyRooms() bob can always take the secret passage from the garage but the likeliest room was %v", rooms[0])
+	}
+	if _, err := game.LikelyRooms(alice); err == nil {
+		t.Error("Game.LikelyRooms() alice hasn't suggested anywhere but her room was known.")
+	}
+}
+
+func TestSuspectsSetTurnOrder(t *testing.T) {
+	game, alice, bob, charlie := GenSampleGame()
+
+	if err := game.SetSuspect(alice, "plum"); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.SetSuspect(bob, "plum"); err == nil {
+		t.Error("Game.SetSuspect() bob was allowed to play as plum too.")
+	}
+	if err := game.SetSuspect(bob, "dagger"); err == nil {
+		t.Error("Game.SetSuspect() bob was allowed to play as a weapon.")
+	}
+
+	// turn order only comes from the suspects once everyone has one
+	if order := game.TurnOrder(); order[0] != game.Me {
+		t.Error("Game.TurnOrder() Changed the order before every suspect was known.")
+	}
+
+	game.SetSuspect(bob, "scarlet")
+	game.SetSuspect(charlie, "white")
+	game.SetSuspect(game.Me, "green")
+
+	want := []*cluedo.Player{bob, charlie, game.Me, alice}
+	if order := game.TurnOrder(); !slices.Equal(order, want) {
+		t.Error("Game.TurnOrder() Players weren't in the order of their suspects, starting with scarlet.")
+	}
+
+	if !strings.Contains(game.String(), "| bob (scarlet) |") || !strings.Contains(game.String(), "| you (green) |") {
+		t.Errorf("Game.String() The header didn't show each player's suspect:\n%s", game.String())
+	}
+}
+
+func TestTurnOrderUnknownSuspectsLast(t *testing.T) {
+	board := cluedo.NewBoard("test", "hall", "lounge", "cellar")
+	board.SetSuspects("scarlet", "plum", "orchid")
+	board.SetWeapons("rope", "dagger")
+	board.SetTurnOrder("scarlet", "plum")
+
+	alice := cluedo.NewPlayer("alice", 2)
+	bob := cluedo.NewPlayer("bob", 2)
+	game := cluedo.NewGameWithBoard(board, alice, bob)
+	game.SetSuspect(game.Me, "orchid")
+	game.SetSuspect(alice, "plum")
+	game.SetSuspect(bob, "scarlet")
+
+	want := []*cluedo.Player{bob, alice, game.Me}
+	if order := game.TurnOrder(); !slices.Equal(order, want) {
+		t.Error("Game.TurnOrder() orchid has no place in the turn order so should have gone last.")
+	}
+}
+
+func TestSuggestionMovesSuspectPlayer(t *testing.T) {
+	game, alice, bob, _ := GenSampleGame()
+	game.SetSuspect(alice, "scarlet")
+
+	question := newQuestion(t, &game,
+		cluedo.NewCard("scarlet"),
+		cluedo.NewCard("rope"),
+		cluedo.NewCard("kitchen"),
+		bob,
+		alice,
+	)
+	question.SetAnswer(cluedo.NoAnswer)
+	game.DoTurn(question)
+
+	if room, _ := game.PlayerRoom(alice); room != "kitchen" {
+		t.Errorf("Game.PlayerRoom() alice plays scarlet who was moved to the kitchen but alice was in `%s`", room)
+	}
+}
+
+func TestSuggestionMovesAskerToken(t *testing.T) {
+	game, alice, bob, _ := GenSampleGame()
+	game.SetSuspect(bob, "mustard")
+
+	question := newQuestion(t, &game,
+		cluedo.NewCard("plum"),
+		cluedo.NewCard("rope"),
+		cluedo.NewCard("garage"),
+		bob,
+		alice,
+	)
+	question.SetAnswer(cluedo.NoAnswer)
+	game.DoTurn(question)
+
+	if room, _ := game.TokenRoom("mustard"); room != "garage" {
+		t.Errorf("Game.TokenRoom() bob plays mustard and suggested in the garage but mustard's token was in `%s`", room)
+	}
+}
+
+func TestBluffRate(t *testing.T) {
+	transcript, err := cluedo.ParseTranscript(strings.NewReader(`players alice=5 bob=5 charlie=4
+hand peacock, white, rope, bathroom
+suggest bob: plum, dagger, kitchen | show charlie`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, err := transcript.Replay(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	held := func(opts cluedo.ProbabilityOptions) float64 {
+		t.Helper()
+		probs, err := game.Probabilities(context.Background(), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return probs.Owner("dagger", "bob")
+	}
+
+	neutral := held(cluedo.ProbabilityOptions{})
+	once := held(cluedo.ProbabilityOptions{BluffRate: 0.6})
+	if once <= neutral {
+		t.Errorf("Game.Probabilities() bob bluffing didn't make his dagger more likely: %v then %v", neutral, once)
+	}
+	if honest := held(cluedo.ProbabilityOptions{BluffRate: 0.6, Priors: map[string]cluedo.PlayerPrior{"bob": {OwnCardRate: 0.05}}}); honest >= neutral {
+		t.Errorf("Game.Probabilities() bob's prior didn't override the bluff rate: %v then %v", neutral, honest)
+	}
+
+	for range 2 {
+		if _, err := game.PlayLine(transcript.Lines[2]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	thrice := held(cluedo.ProbabilityOptions{BluffRate: 0.6})
+	if thrice <= once {
+		t.Errorf("Game.Probabilities() bob asking about the dagger again didn't make it more likely his: %v then %v", once, thrice)
+	}
+	if again := held(cluedo.ProbabilityOptions{}); again != neutral {
+		t.Errorf("Game.Probabilities() repeating a suggestion changed the neutral chance from %v to %v", neutral, again)
+	}
+
+	probs, err := game.Probabilities(context.Background(), cluedo.ProbabilityOptions{BluffRate: 0.6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bluffs := game.Bluffs(probs)
+	if len(bluffs) != 3 {
+		t.Fatalf("Game.Bluffs() Gave %v but bob suggested 3 cards.", bluffs)
+	}
+	for _, b := range bluffs {
+		if b.Asker != "bob" || b.Times != 3 {
+			t.Errorf("Game.Bluffs() Gave %+v but bob suggested each card 3 times.", b)
+		}
+	}
+	if i := slices.IndexFunc(bluffs, func(b cluedo.Bluff) bool { return b.Card == "dagger" }); i < 0 || math.Abs(bluffs[i].Held-thrice) > 1e-9 {
+		t.Errorf("Game.Bluffs() Didn't match the probabilities for the dagger: %v", bluffs)
 	}
 }
@@ -0,0 +1,58 @@
+package cluedo
+
+import "slices"
+
+const EnvelopeIdent = "ENVELOPE"
+
+func (g *Game) categories() []CardCategory {
+	return []CardCategory{
+		g.whoCategory,
+		g.whatCategory,
+		g.whereCategory,
+	}
+}
+
+// CategoryCards lists the cards in the who, what or where category.
+func (g *Game) CategoryCards(category string) []*Card {
+	i := slices.Index(categoryNames, category)
+	if i < 0 {
+		return nil
+	}
+	return slices.Clone(g.categories()[i].Cards)
+}
+
+// possibleOwners lists the players that could still be holding the card. It
+// doesn't include the envelope.
+func (g *Game) possibleOwners(c *Card) []*Player {
+	owners := []*Player{}
+	for _, p := range g.k.possibleOwners(c.index) {
+		owners = append(owners, g.players[p])
+	}
+	return owners
+}
+
+// UpdateEnvelope treats the envelope as one more player that holds exactly
+// one card from each category. Any card the envelope can't have must be in
+// someone's hand, so if only one player is left who could have it then it's
+// theirs.
+func (g *Game) UpdateEnvelope() {
+	k := g.k
+	k.envelopeCandidates &^= k.found
+
+	for _, category := range g.categories() {
+		cards := category.set()
+
+		if murder := k.murder & cards; murder != 0 {
+			k.envelopeCandidates &^= cards &^ murder
+		}
+		if candidates := k.envelopeCandidates & cards; candidates.len() == 1 {
+			k.murder |= candidates
+		}
+
+		for _, c := range (cards &^ k.envelopeCandidates &^ k.found).indices() {
+			if owners := k.possibleOwners(c); len(owners) == 1 {
+				k.setFound(c, owners[0])
+			}
+		}
+	}
+}
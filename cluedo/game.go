@@ -13,62 +13,102 @@ type Game struct {
 	whatCategory  CardCategory
 	whereCategory CardCategory
 
-	players []*Player
-	Me      *Player
+	board *Board
+
+	players  []*Player
+	Me       *Player
+	Envelope *Player
+
+	// every card in the game in who, what, where order. A card's index in
+	// here is its index in k
+	cards []*Card
+	k     *knowledge
+
+	// where each suspect's token and each player were last seen
+	tokenRooms  map[CardID]CardID
+	playerRooms map[PlayerID]CardID
+	// the suspect each player is playing as
+	suspects map[PlayerID]CardID
+
+	// our starting hand and every question played since, so the game can be
+	// played again from the start
+	hand    cardSet
+	history []turnRecord
+
+	turn            int
+	constraintTurns map[string]int
+}
+
+type turnRecord struct {
+	turn     int
+	question Question
 }
 
 const MeIdent = "ME"
 
 func NewDefaultGame(otherPlayers ...*Player) Game {
+	return NewGameWithBoard(DefaultBoard(), otherPlayers...)
+}
+
+// NewGameWithBoard starts a game played with the suspects, weapons and rooms
+// of board's edition.
+func NewGameWithBoard(board *Board, otherPlayers ...*Player) Game {
+	category := func(names []string) CardCategory {
+		cards := []*Card{}
+		for _, name := range names {
+			cards = append(cards, NewCard(name))
+		}
+		return NewCardCategory(cards...)
+	}
+
 	g := Game{
-		whoCategory: NewCardCategory(
-			NewCard("green"),
-			NewCard("mustard"),
-			NewCard("peacock"),
-			NewCard("plum"),
-			NewCard("scarlet"),
-			NewCard("white"),
-		),
-		whatCategory: NewCardCategory(
-			NewCard("wrench"),
-			NewCard("candlestick"),
-			NewCard("dagger"),
-			NewCard("pistol"),
-			NewCard("lead pipe"),
-			NewCard("rope"),
-		),
-		whereCategory: NewCardCategory(
-			NewCard("bathroom"),
-			NewCard("study"),
-			NewCard("dining room"),
-			NewCard("games room"),
-			NewCard("garage"),
-			NewCard("bedroom"),
-			NewCard("living room"),
-			NewCard("kitchen"),
-			NewCard("courtyard"),
-		),
+		whoCategory:     category(board.suspects),
+		whatCategory:    category(board.weapons),
+		whereCategory:   category(board.rooms),
+		board:           board,
+		tokenRooms:      map[CardID]CardID{},
+		playerRooms:     map[PlayerID]CardID{},
+		suspects:        map[PlayerID]CardID{},
+		constraintTurns: map[string]int{},
+	}
+
+	names := map[string]bool{}
+	for _, c := range g.GetAllCards() {
+		if names[c.name] {
+			panic(fmt.Sprintf("Can't have 2 cards called `%s`", c.name))
+		}
+		names[c.name] = true
+	}
+	for _, category := range g.categories() {
+		if len(category.Cards) == 0 {
+			panic(fmt.Sprintf("The %s board is missing a category of cards", board.edition))
+		}
 	}
 
 	g.Me = NewPlayer(MeIdent, 0)
+	g.Envelope = NewPlayer(EnvelopeIdent, len(g.categories()))
 
 	g.players = append(g.players, g.Me)
 	for _, player := range otherPlayers {
 
-		if player.name == MeIdent {
-			panic(fmt.Sprintf("Can't have a player called `%s`", MeIdent))
+		if player.name == MeIdent || player.name == EnvelopeIdent {
+			panic(fmt.Sprintf("Can't have a player called `%s`", player.name))
 		}
-		if slices.Contains(g.players, player) {
+		if slices.ContainsFunc(g.players, func(p *Player) bool { return p.name == player.name }) {
 			panic("Can't have 2 players with the same name")
 		}
 
 		g.players = append(g.players, player)
 	}
 
+	g.cards = g.GetAllCards()
+	g.k = newKnowledge(len(g.cards), g.players, g.Envelope)
+	attachCards(g.k, g.cards)
+
 	return g
 }
 
-func (g Game) String() string {
+func (g *Game) String() string {
 	// setup
 
 	longestCardNameLen := 0
@@ -79,15 +119,13 @@ func (g Game) String() string {
 	}
 
 	// player list setup
-	playerList := "|  " + strings.Repeat(" ", longestCardNameLen) + "| you |"
-	columnSpacing := []int{longestCardNameLen, 3}
+	playerList := "|  " + strings.Repeat(" ", longestCardNameLen) + "|"
+	columnSpacing := []int{longestCardNameLen}
 
 	for _, player := range g.players {
-		if player.name == MeIdent {
-			continue
-		}
-		playerList += fmt.Sprintf(" %v |", player.name)
-		columnSpacing = append(columnSpacing, len(player.name))
+		label := g.playerLabel(player)
+		playerList += fmt.Sprintf(" %v |", label)
+		columnSpacing = append(columnSpacing, len(label))
 	}
 
 	allColumnWidth := len(playerList)
@@ -114,9 +152,9 @@ func (g Game) String() string {
 
 				str.WriteString(" ")
 
-				if card.possessor == g.players[i-1] {
+				if card.Possessor() == g.players[i-1] {
 					str.WriteString("✓")
-				} else if slices.Contains(card.nonPossessors, g.players[i-1]) {
+				} else if card.isNonPossessor(g.players[i-1]) {
 					str.WriteString("x")
 				} else {
 					str.WriteString(" ")
@@ -128,12 +166,12 @@ func (g Game) String() string {
 
 			if card.IsFound() {
 				str.WriteString(" ")
-				if card.possessor.name != MeIdent {
-					str.WriteString(string(card.possessor.name))
+				if card.Possessor().name != MeIdent {
+					str.WriteString(string(card.Possessor().name))
 				} else {
 					str.WriteString("you")
 				}
-			} else if card.isMurderItem {
+			} else if card.IsMurderItem() {
 				str.WriteString(" MURDER ELEMENT")
 			}
 
@@ -148,7 +186,7 @@ func (g Game) String() string {
 	return str.String()
 }
 
-func (g Game) GetAllCards() []*Card {
+func (g *Game) GetAllCards() []*Card {
 	allCards := []*Card{}
 	allCards = append(allCards, g.whoCategory.Cards...)
 	allCards = append(allCards, g.whatCategory.Cards...)
@@ -158,239 +196,148 @@ func (g Game) GetAllCards() []*Card {
 
 func (g *Game) AddStartingHand(hand []*Card) {
 	for _, c := range hand {
-		if g.whoCategory.FoundCard(c, g.Me) {
-			continue
+		id, err := g.cardID(c)
+		if err != nil {
+			panic(fmt.Sprintf("Can't have card `%v` in hand because it's not in the game.", c.name))
 		}
-		if g.whatCategory.FoundCard(c, g.Me) {
-			continue
-		}
-		if g.whereCategory.FoundCard(c, g.Me) {
-			continue
-		}
-		panic(fmt.Sprintf("Can't have card `%v` in hand because it's not in the game.", c.name))
+		g.CardByID(id).SetFound(g.Me)
 	}
 
 	g.Me.cardCount = len(hand)
+	g.hand = g.k.owned[g.k.playerIndex(g.Me)]
 
-	for _, c := range g.GetAllCards() {
-		if c.possessor != g.Me {
-			c.nonPossessors = append(c.nonPossessors, g.Me)
-		}
-	}
+	me := g.k.playerIndex(g.Me)
+	g.k.possible[me] = g.k.owned[me]
 	g.Update()
 }
 
 func (g *Game) Update() {
-	g.UpdateCompleteCategories()
-	g.UpdateNonPossessors()
-	g.UpdateCompletePlayers()
+	// each rule can give the others something new to work with so keep
+	// going until nothing changes
+	for {
+		before := g.k.clone()
+
+		g.UpdateCompleteCategories()
+		g.UpdateNonPossessors()
+		g.UpdateEnvelope()
+		g.UpdateClauses()
+		g.UpdateClauseCardinality()
+		g.UpdateCompletePlayers()
+		g.UpdateShows()
+
+		if g.k.equal(before) {
+			return
+		}
+	}
 }
 
 func (g *Game) UpdateNonPossessors() {
-	allCards := g.GetAllCards()
-
-	for _, c := range allCards {
-		if !c.IsFound() && !c.isMurderItem {
-			continue
-		}
-		for _, p := range g.players {
-			if p == c.possessor {
-				continue
-			}
-			c.AddNonPossessor(p)
-		}
+	known := g.k.found | g.k.murder
+	for p := range g.k.possible {
+		g.k.possible[p] &^= known &^ g.k.owned[p]
 	}
 }
 
 func (g *Game) UpdateCompletePlayers() {
-	allCards := g.GetAllCards()
-
-	for _, player := range g.players {
+	for p, player := range g.players {
 		if player == g.Me {
 			continue
 		}
 
-		foundCardCount := 0
-		unfoundCardCount := 0
-		unknownCards := []*Card{}
-
-		for _, c := range allCards {
-			if slices.Contains(c.nonPossessors, player) {
-				unfoundCardCount++
-			} else if c.IsFound() {
-				if c.possessor == player {
-					foundCardCount++
-				}
-			}
-
-			if !slices.Contains(c.nonPossessors, player) && c.possessor != player {
-				unknownCards = append(unknownCards, c)
-			}
+		owned := g.k.owned[p]
+		if owned.len() == player.cardCount {
+			g.k.possible[p] = owned
+			continue
 		}
 
-		if foundCardCount == player.cardCount {
-			for _, c := range allCards {
-				if c.possessor == player {
-					continue
-				}
-				c.AddNonPossessor(player)
-			}
-		} else {
-			cardsLeftToFind := player.cardCount - foundCardCount
-
-			if len(unknownCards) == cardsLeftToFind {
-				for _, c := range unknownCards {
-					c.SetFound(player, true)
-				}
+		unknown := g.k.possible[p] &^ g.k.found &^ g.k.murder
+		if unknown.len() == player.cardCount-owned.len() {
+			for _, c := range unknown.indices() {
+				g.k.setFound(c, p)
 			}
 		}
-
 	}
 }
 
 func (g *Game) UpdateCompleteCategories() {
-	g.whoCategory.UpdateMurderKnowledge()
-	g.whatCategory.UpdateMurderKnowledge()
-	g.whereCategory.UpdateMurderKnowledge()
-
-	for _, c := range g.GetAllCards() {
-		if len(c.nonPossessors) == len(g.players) {
-			c.isMurderItem = true
-		}
-	}
-}
-
-func (g Game) EnsureValidQuestion(question Question) bool {
-	if !slices.ContainsFunc(g.whoCategory.Cards, func(c *Card) bool { return c.name == question.whoPart.name }) {
-		return false
-	}
-	if !slices.ContainsFunc(g.whatCategory.Cards, func(c *Card) bool { return c.name == question.whatPart.name }) {
-		return false
-	}
-	if !slices.ContainsFunc(g.whereCategory.Cards, func(c *Card) bool { return c.name == question.wherePart.name }) {
-		return false
-	}
-	if !slices.Contains(g.players, question.asker) {
-		return false
-	}
-	if !slices.Contains(g.players, question.answerer) {
-		return false
+	for _, category := range g.categories() {
+		g.k.solveCategory(category.set())
 	}
 
-	return true
+	g.k.murder |= allCards(len(g.cards)) &^ g.k.held()
 }
 
-func (g *Game) DoTurn(question Question) {
-	g.EnsureValidQuestion(question)
+func (g *Game) EnsureValidQuestion(question Question) bool {
+	return g.ValidateQuestion(question) == nil
+}
 
-	// we already know our own cards so don't need to analyse
-	if question.answerer == g.Me {
-		return
+func (g *Game) Card(name string) *Card {
+	id, err := g.LookupCard(name)
+	if err != nil {
+		return nil
 	}
+	return g.CardByID(id)
+}
 
-	switch question.answer {
-	case UnknownAnswer:
-		g.analyseUnknownAnswer(question)
-	case NoAnswer:
-		for _, c := range g.whoCategory.Cards {
-			if c.name == question.whoPart.name {
-				c.AddNonPossessor(question.answerer)
-				break
-			}
-		}
-		for _, c := range g.whatCategory.Cards {
-			if c.name == question.whatPart.name {
-				c.AddNonPossessor(question.answerer)
-				break
-			}
-		}
-		for _, c := range g.whereCategory.Cards {
-			if c.name == question.wherePart.name {
-				c.AddNonPossessor(question.answerer)
-				break
-			}
-		}
-	case WhoAnswer:
-		g.whoCategory.FoundCard(question.whoPart, question.answerer)
-	case WhatAnswer:
-		g.whatCategory.FoundCard(question.whatPart, question.answerer)
-	case WhereAnswer:
-		g.whereCategory.FoundCard(question.wherePart, question.answerer)
+func (g *Game) Player(name string) *Player {
+	id, err := g.LookupPlayer(name)
+	if err != nil {
+		return nil
 	}
-	g.Update()
+	return g.PlayerByID(id)
 }
 
-func (g *Game) analyseUnknownAnswer(question Question) {
-	var gameWho, gameWhat, gameWhere *Card
-	for _, c := range g.whoCategory.Cards {
-		if c.name == question.whoPart.name {
-			gameWho = c
-			break
-		}
-	}
-	for _, c := range g.whatCategory.Cards {
-		if c.name == question.whatPart.name {
-			gameWhat = c
-			break
-		}
-	}
-	for _, c := range g.whereCategory.Cards {
-		if c.name == question.wherePart.name {
-			gameWhere = c
-			break
-		}
+// DoTurn plays a question and its answer, returning everything that was
+// learnt. A question that isn't valid is rejected without using up a turn.
+func (g *Game) DoTurn(question Question) (TurnResult, error) {
+	if err := g.ValidateQuestion(question); err != nil {
+		return TurnResult{}, err
 	}
 
-	// all the cards are already known so no new information can be gathered
-	if gameWho.IsFound() && gameWhat.IsFound() && gameWhere.IsFound() {
-		return
-	}
+	g.turn++
+	before := g.Snapshot()
 
-	// if we already know the answerer has one of the cards in question we can't gather any useful information
-	if gameWho.possessor == question.answerer || gameWhat.possessor == question.answerer || gameWhere.possessor == question.answerer {
-		return
+	g.doTurn(question)
+
+	shown := ""
+	if c, ok := question.shown(); ok {
+		shown = g.CardByID(c).name
 	}
 
-	// i know the card isn't owned by the answerer
-	whoFound := gameWho.IsFound() && gameWho.possessor != question.answerer
-	whatFound := gameWhat.IsFound() && gameWhat.possessor != question.answerer
-	whereFound := gameWhere.IsFound() && gameWhere.possessor != question.answerer
+	return diffSnapshots(before, g.Snapshot(), g.turn, g.constraintTurns, shown), nil
+}
 
-	// simple 2 knowns from question
-	if whoFound && whatFound {
-		gameWhere.SetFound(question.answerer, true)
-		return
-	}
-	if whoFound && whereFound {
-		gameWhat.SetFound(question.answerer, true)
-		return
-	}
-	if whatFound && whereFound {
-		gameWho.SetFound(question.answerer, true)
+func (g *Game) doTurn(r Question) {
+	g.history = append(g.history, turnRecord{
+		turn:     g.turn,
+		question: r,
+	})
+	g.moveTokens(r)
+	g.recordShow(r)
+
+	// we already know our own cards so only the asker learnt anything
+	if g.PlayerByID(r.answerer) == g.Me {
+		g.Update()
 		return
 	}
 
-	// 1 known from question link creation
-	if whoFound && !(gameWhere.IsFound() || gameWhat.IsFound()) {
-		gameWhere.AddLink(question.answerer, gameWhat)
-		gameWhat.AddLink(question.answerer, gameWhere)
-		return
-	}
-	if whatFound && !(gameWhere.IsFound() || gameWho.IsFound()) {
-		gameWhere.AddLink(question.answerer, gameWho)
-		gameWho.AddLink(question.answerer, gameWhere)
-		return
-	}
-	if whereFound && !(gameWho.IsFound() || gameWhat.IsFound()) {
-		gameWho.AddLink(question.answerer, gameWhat)
-		gameWhat.AddLink(question.answerer, gameWho)
-		return
+	answerer := int(r.answerer)
+	switch r.answer {
+	case UnknownAnswer:
+		g.analyseUnknownAnswer(r)
+	case NoAnswer:
+		for _, c := range r.cards {
+			g.k.possible[answerer] = g.k.possible[answerer].without(int(c))
+		}
+	case WhoAnswer, WhatAnswer, WhereAnswer:
+		card, _ := r.shown()
+		g.k.setFound(int(card), answerer)
 	}
+	g.Update()
+}
 
-	// no knowns from question
-	if !(whoFound || whatFound || whereFound) {
-		gameWho.AddTriLink(question.answerer, gameWhat, gameWhere)
-		gameWhat.AddTriLink(question.answerer, gameWho, gameWhere)
-		gameWhere.AddTriLink(question.answerer, gameWhat, gameWho)
-	}
+func (g *Game) analyseUnknownAnswer(r Question) {
+	// the answerer has at least one of the cards. Anything already known
+	// about them is simplified away when the game next updates
+	g.AddClause(g.PlayerByID(r.answerer), g.CardByID(r.cards[0]), g.CardByID(r.cards[1]), g.CardByID(r.cards[2]))
 }
@@ -0,0 +1,95 @@
+package cluedo_test
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata with the current output")
+
+// TestGoldenTranscripts replays every transcript in testdata and compares the
+// final snapshot with the matching .golden file. To add a case drop a new
+// transcript in testdata and run `go test ./cluedo -run Golden -update`.
+func TestGoldenTranscripts(t *testing.T) {
+	transcripts, err := filepath.Glob(filepath.Join("testdata", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transcripts) == 0 {
+		t.Fatal("no transcripts found in testdata")
+	}
+
+	for _, path := range transcripts {
+		name := strings.TrimSuffix(filepath.Base(path), ".txt")
+		t.Run(name, func(t *testing.T) {
+			file, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			transcript, err := cluedo.ParseTranscript(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			game, err := transcript.Replay(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// a golden is only worth keeping if the game could really happen
+			if _, err := game.Probabilities(context.Background(), cluedo.ProbabilityOptions{}); err != nil {
+				t.Fatalf("%s can't be a real game: %v", path, err)
+			}
+
+			got, err := game.Snapshot().JSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			goldenPath := strings.TrimSuffix(path, ".txt") + ".golden"
+			if *update {
+				if err := os.WriteFile(goldenPath, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("snapshot of %s doesn't match %s\n%s", path, goldenPath, lineDiff(string(want), string(got)))
+			}
+		})
+	}
+}
+
+func lineDiff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	diff := strings.Builder{}
+	for i := 0; i < max(len(wantLines), len(gotLines)); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			diff.WriteString("line " + strconv.Itoa(i+1) + ":\n-" + w + "\n+" + g + "\n")
+		}
+	}
+	return diff.String()
+}
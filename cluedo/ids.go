@@ -0,0 +1,136 @@
+package cluedo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CardID identifies a card in a game. It's the card's position in the game so
+// it stays the same in clones of the game.
+type CardID int
+
+// PlayerID identifies a player in a game. It's the player's position in the
+// turn order, with ME first.
+type PlayerID int
+
+func (c *Card) ID() CardID {
+	return CardID(c.index)
+}
+
+func (g *Game) LookupCard(name string) (CardID, error) {
+	for _, c := range g.cards {
+		if c.name == name {
+			return c.ID(), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown card `%s`", name)
+}
+
+func (g *Game) LookupPlayer(name string) (PlayerID, error) {
+	for i, p := range g.players {
+		if p.name == name {
+			return PlayerID(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown player `%s`", name)
+}
+
+func (g *Game) CardByID(id CardID) *Card {
+	if id < 0 || int(id) >= len(g.cards) {
+		return nil
+	}
+	return g.cards[id]
+}
+
+func (g *Game) PlayerByID(id PlayerID) *Player {
+	if id < 0 || int(id) >= len(g.players) {
+		return nil
+	}
+	return g.players[id]
+}
+
+// NewQuestion makes a question from IDs in the game. It's checked when it's
+// played.
+func (g *Game) NewQuestion(who, what, where CardID, asker, answerer PlayerID) Question {
+	return Question{
+		cards:    [3]CardID{who, what, where},
+		asker:    asker,
+		answerer: answerer,
+	}
+}
+
+// QuestionFor makes a question from cards and players that may have been made
+// outside of the game, looking each one up by name.
+func (g *Game) QuestionFor(who, what, where *Card, asker, answerer *Player) (Question, error) {
+	q := Question{}
+	for i, c := range []*Card{who, what, where} {
+		id, err := g.cardID(c)
+		if err != nil {
+			name := "nothing"
+			if c != nil {
+				name = c.name
+			}
+			return q, fmt.Errorf("`%s` isn't a %s card", name, categoryNames[i])
+		}
+		q.cards[i] = id
+	}
+
+	if asker == nil || answerer == nil {
+		return q, errors.New("question needs an asker and an answerer")
+	}
+	a := g.k.playerIndex(asker)
+	if a < 0 {
+		return q, fmt.Errorf("asker `%s` isn't playing", asker.name)
+	}
+	b := g.k.playerIndex(answerer)
+	if b < 0 {
+		return q, fmt.Errorf("answerer `%s` isn't playing", answerer.name)
+	}
+	q.asker = PlayerID(a)
+	q.answerer = PlayerID(b)
+
+	return q, g.ValidateQuestion(q)
+}
+
+// cardID resolves a card that may have been made outside of the game.
+func (g *Game) cardID(c *Card) (CardID, error) {
+	if c == nil {
+		return 0, errors.New("missing card")
+	}
+	if c.k == g.k && c.k != nil {
+		return c.ID(), nil
+	}
+	return g.LookupCard(c.name)
+}
+
+// ValidateQuestion checks everything in the question is part of the game and
+// that it makes sense.
+func (g *Game) ValidateQuestion(q Question) error {
+	if err := g.validateCards(q.cards); err != nil {
+		return err
+	}
+
+	asker := g.PlayerByID(q.asker)
+	if asker == nil || g.PlayerByID(q.answerer) == nil {
+		return errors.New("question needs an asker and an answerer in the game")
+	}
+	if q.asker == q.answerer {
+		return fmt.Errorf("`%s` can't answer their own question", asker.name)
+	}
+	return nil
+}
+
+// validateCards checks the cards are in the game and go who, what, where.
+func (g *Game) validateCards(cards [3]CardID) error {
+	categories := g.categories()
+	for i, id := range cards {
+		c := g.CardByID(id)
+		if c == nil {
+			return fmt.Errorf("card %d isn't in the game", id)
+		}
+		if !categories[i].set().has(int(id)) {
+			return fmt.Errorf("`%s` isn't a %s card", c.name, categoryNames[i])
+		}
+	}
+	return nil
+}
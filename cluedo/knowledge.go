@@ -0,0 +1,187 @@
+package cluedo
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// cardSet is a set of cards using their index in the game.
+type cardSet uint64
+
+const maxCards = 64
+
+func (s cardSet) has(i int) bool {
+	return s&(1<<i) != 0
+}
+
+func (s cardSet) with(i int) cardSet {
+	return s | 1<<i
+}
+
+func (s cardSet) without(i int) cardSet {
+	return s &^ (1 << i)
+}
+
+func (s cardSet) len() int {
+	return bits.OnesCount64(uint64(s))
+}
+
+func (s cardSet) first() int {
+	return bits.TrailingZeros64(uint64(s))
+}
+
+func (s cardSet) indices() []int {
+	indices := []int{}
+	for s != 0 {
+		i := s.first()
+		indices = append(indices, i)
+		s = s.without(i)
+	}
+	return indices
+}
+
+func allCards(count int) cardSet {
+	if count == maxCards {
+		return ^cardSet(0)
+	}
+	return 1<<count - 1
+}
+
+// knowledge is everything known about where the cards are. Cards are views
+// over it so copying it copies the state of the whole game.
+type knowledge struct {
+	players  []*Player
+	envelope *Player
+
+	// every card each player could still have, including the ones they're
+	// known to have
+	possible []cardSet
+	// cards each player is known to have
+	owned []cardSet
+	// cards known to be in a player's hand. Usually the same as all the owned
+	// cards but a card can be found without knowing who has it
+	found cardSet
+	// cards that could still be in the envelope
+	envelopeCandidates cardSet
+	// cards known to be in the envelope
+	murder cardSet
+
+	clauses []cardClause
+
+	// cards each player is known to have seen, in their own hand or shown
+	// to them
+	seen []cardSet
+	// shows we watched without seeing which card it was
+	shows []cardShow
+}
+
+// cardClause is a Clause over card indices. player is an index into
+// knowledge.players.
+type cardClause struct {
+	player int
+	cards  cardSet
+}
+
+func newKnowledge(cardCount int, players []*Player, envelope *Player) *knowledge {
+	if cardCount > maxCards {
+		panic("Can't have more than 64 cards in a game")
+	}
+
+	k := &knowledge{
+		players:            players,
+		envelope:           envelope,
+		envelopeCandidates: allCards(cardCount),
+	}
+	for range players {
+		k.possible = append(k.possible, allCards(cardCount))
+		k.owned = append(k.owned, 0)
+		k.seen = append(k.seen, 0)
+	}
+	return k
+}
+
+// clone copies the knowledge. The players are shared so the caller should
+// replace them if the copy belongs to a different game.
+func (k *knowledge) clone() *knowledge {
+	clone := *k
+	clone.players = slices.Clone(k.players)
+	clone.possible = slices.Clone(k.possible)
+	clone.owned = slices.Clone(k.owned)
+	clone.clauses = slices.Clone(k.clauses)
+	clone.seen = slices.Clone(k.seen)
+	clone.shows = slices.Clone(k.shows)
+	return &clone
+}
+
+func (k *knowledge) equal(other *knowledge) bool {
+	return k.found == other.found &&
+		k.envelopeCandidates == other.envelopeCandidates &&
+		k.murder == other.murder &&
+		slices.Equal(k.possible, other.possible) &&
+		slices.Equal(k.owned, other.owned) &&
+		slices.Equal(k.clauses, other.clauses) &&
+		slices.Equal(k.seen, other.seen) &&
+		slices.Equal(k.shows, other.shows)
+}
+
+func (k *knowledge) playerIndex(p *Player) int {
+	return slices.Index(k.players, p)
+}
+
+func (k *knowledge) setFound(card int, player int) {
+	k.found = k.found.with(card)
+	if player >= 0 {
+		k.owned[player] = k.owned[player].with(card)
+	}
+}
+
+func (k *knowledge) owner(card int) int {
+	for p, owned := range k.owned {
+		if owned.has(card) {
+			return p
+		}
+	}
+	return -1
+}
+
+// possibleOwners lists the index of every player that could have the card.
+func (k *knowledge) possibleOwners(card int) []int {
+	owners := []int{}
+	for p, possible := range k.possible {
+		if possible.has(card) {
+			owners = append(owners, p)
+		}
+	}
+	return owners
+}
+
+// solveCategory marks the last card of a category that isn't found as the
+// murder card.
+func (k *knowledge) solveCategory(cards cardSet) {
+	if k.murder&cards != 0 {
+		return
+	}
+	if left := cards &^ k.found; left.len() == 1 {
+		k.murder |= left
+	}
+}
+
+// held is every card at least one player could have.
+func (k *knowledge) held() cardSet {
+	held := cardSet(0)
+	for _, possible := range k.possible {
+		held |= possible
+	}
+	return held
+}
+
+// unknowns counts everything that isn't known yet: every player that could
+// have a card that isn't theirs and every card that could be in the envelope
+// that isn't known to be.
+func (k *knowledge) unknowns() int {
+	unknowns := (k.envelopeCandidates &^ k.murder).len()
+	for p, possible := range k.possible {
+		unknowns += (possible &^ k.owned[p]).len()
+	}
+	return unknowns
+}
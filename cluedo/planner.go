@@ -0,0 +1,220 @@
+package cluedo
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+)
+
+// RollChance is the chance of rolling n with two dice.
+func RollChance(n int) float64 {
+	if n < 2 || n > 12 {
+		return 0
+	}
+	return float64(6-abs(n-7)) / 36
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// reachChance is the chance of getting at least steps with two dice over
+// turns turns.
+func reachChance(steps int, turns int) float64 {
+	if steps <= 0 {
+		return 1
+	}
+	if turns <= 0 {
+		return 0
+	}
+
+	chance := 0.0
+	for roll := 2; roll <= 12; roll++ {
+		if roll >= steps {
+			chance += RollChance(roll)
+		} else {
+			chance += RollChance(roll) * reachChance(steps-roll, turns-1)
+		}
+	}
+	return chance
+}
+
+// a room only reached on the second turn is worth less because the turn it
+// took could have been spent suggesting somewhere else
+const laterTurnDiscount = 0.5
+
+type PlanOptions struct {
+	// how many turns ahead to look, either 1 or 2. Defaults to 2
+	Turns int
+
+	// the probabilities to estimate answers with. They're counted from the
+	// game if left out
+	Probabilities *Probabilities
+}
+
+// RoomPlan is how worthwhile it is to head for a room.
+type RoomPlan struct {
+	Room string
+	// the room can be reached through a secret passage without rolling
+	Passage bool
+	// chance of being in the room to suggest by each of the next turns
+	Reach []float64
+
+	// the best suggestion to make in the room
+	Who  string
+	What string
+	// how many more things we'd expect to know after making it. Each
+	// player that's ruled out of a card or card that's placed counts as one
+	Gain float64
+
+	// Gain weighted by the chance of getting there in time
+	Value float64
+}
+
+// PlanMove rates every room we could head for from room, best first. Answers
+// to each suggestion are estimated from the chance each player has each card,
+// and the information is how much would be deduced from that answer.
+func (g *Game) PlanMove(ctx context.Context, room string, opts PlanOptions) ([]RoomPlan, error) {
+	turns := opts.Turns
+	if turns <= 0 {
+		turns = 2
+	}
+	if turns > 2 {
+		return nil, errors.New("can only plan 1 or 2 turns ahead")
+	}
+
+	if _, err := g.board.room(room); err != nil {
+		return nil, err
+	}
+
+	probs := opts.Probabilities
+	if probs == nil {
+		p, err := g.Probabilities(ctx, ProbabilityOptions{})
+		if err != nil {
+			return nil, err
+		}
+		probs = &p
+	}
+
+	plans := []RoomPlan{}
+	for _, target := range g.board.Rooms() {
+		if target == room {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		plan := RoomPlan{
+			Room: target,
+		}
+		steps, _ := g.board.Distance(room, target)
+		if steps == -1 {
+			continue
+		}
+		if passage, ok := g.board.SecretPassage(room); ok && passage == target {
+			plan.Passage = true
+		}
+
+		for turn := 1; turn <= turns; turn++ {
+			plan.Reach = append(plan.Reach, reachChance(steps, turn))
+		}
+
+		plan.Who, plan.What, plan.Gain = g.bestSuggestion(target, *probs)
+
+		plan.Value = plan.Gain * plan.Reach[0]
+		if turns == 2 {
+			plan.Value += plan.Gain * (plan.Reach[1] - plan.Reach[0]) * laterTurnDiscount
+		}
+		plans = append(plans, plan)
+	}
+
+	slices.SortStableFunc(plans, func(a, b RoomPlan) int {
+		return cmp.Compare(b.Value, a.Value)
+	})
+	return plans, nil
+}
+
+// bestSuggestion finds the who and what to suggest in room that we'd expect
+// to learn the most from.
+func (g *Game) bestSuggestion(room string, probs Probabilities) (string, string, float64) {
+	where, err := g.LookupCard(room)
+	if err != nil {
+		return "", "", 0
+	}
+
+	bestWho, bestWhat, best := "", "", -1.0
+	for _, who := range g.whoCategory.Cards {
+		for _, what := range g.whatCategory.Cards {
+			gain := g.suggestionGain([3]CardID{who.ID(), what.ID(), where}, probs)
+			if gain > best {
+				bestWho, bestWhat, best = who.name, what.name, gain
+			}
+		}
+	}
+	return bestWho, bestWhat, best
+}
+
+// suggestionGain is how much we'd expect to learn from suggesting cards.
+// Each player after us in turn order either passes or shows one of the cards they hold,
+// which is treated as equally likely to be any of them.
+func (g *Game) suggestionGain(cards [3]CardID, probs Probabilities) float64 {
+	order := g.TurnOrder()
+	me := slices.Index(order, g.Me)
+
+	gain := 0.0
+	passed := []int{}
+	reach := 1.0
+	for i := 1; i < len(g.players) && reach > 0; i++ {
+		answerer := g.k.playerIndex(order[(me+i)%len(order)])
+
+		has := [3]float64{}
+		total := 0.0
+		none := 1.0
+		for j, c := range cards {
+			has[j] = probs.Cards[c].Owners[answerer]
+			total += has[j]
+			none *= 1 - has[j]
+		}
+
+		for j, c := range cards {
+			if has[j] == 0 {
+				continue
+			}
+			chance := reach * (1 - none) * has[j] / total
+			gain += chance * g.outcomeGain(cards, passed, answerer, int(c))
+		}
+
+		reach *= none
+		passed = append(passed, answerer)
+	}
+	if reach > 0 {
+		gain += reach * g.outcomeGain(cards, passed, -1, -1)
+	}
+	return gain
+}
+
+// outcomeGain plays out passes followed by answerer showing us shown on a
+// copy of the game and counts how much more is known afterwards.
+func (g *Game) outcomeGain(cards [3]CardID, passes []int, answerer int, shown int) float64 {
+	sim, _ := g.clone()
+	before := sim.k.unknowns()
+
+	asked := cardSet(0)
+	for _, c := range cards {
+		asked = asked.with(int(c))
+	}
+	for _, p := range passes {
+		sim.k.possible[p] &^= asked
+	}
+	if answerer >= 0 {
+		sim.k.setFound(shown, answerer)
+	}
+	sim.Update()
+
+	return float64(before - sim.k.unknowns())
+}
@@ -0,0 +1,107 @@
+package cluedo_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
+)
+
+func TestRollChance(t *testing.T) {
+	total := 0.0
+	for n := 0; n <= 13; n++ {
+		total += cluedo.RollChance(n)
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("RollChance() Chances add up to %v instead of 1", total)
+	}
+	if cluedo.RollChance(7) != 6.0/36 {
+		t.Errorf("RollChance() 7 should be the most likely roll at 6/36 but was %v", cluedo.RollChance(7))
+	}
+}
+
+func genPlanGame() cluedo.Game {
+	game := cluedo.NewDefaultGame(
+		cluedo.NewPlayer("alice", 5),
+		cluedo.NewPlayer("bob", 5),
+		cluedo.NewPlayer("charlie", 4),
+	)
+	game.AddStartingHand([]*cluedo.Card{
+		cluedo.NewCard("peacock"),
+		cluedo.NewCard("rope"),
+		cluedo.NewCard("study"),
+		cluedo.NewCard("kitchen"),
+	})
+	return game
+}
+
+func TestPlanMove(t *testing.T) {
+	game := genPlanGame()
+
+	plans, err := game.PlanMove(context.Background(), "bathroom", cluedo.PlanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) != len(game.Board().Rooms())-1 {
+		t.Errorf("Game.PlanMove() Expected a plan for every other room but got %d", len(plans))
+	}
+
+	for i, plan := range plans {
+		if plan.Room == "bathroom" {
+			t.Error("Game.PlanMove() Planned to stay in the room we're leaving.")
+		}
+		if i > 0 && plan.Value > plans[i-1].Value {
+			t.Error("Game.PlanMove() Plans weren't sorted best first.")
+		}
+		if plan.Reach[0] > plan.Reach[1] {
+			t.Errorf("Game.PlanMove() %s was more likely to be reached in 1 turn than 2", plan.Room)
+		}
+		if plan.Who == "" || plan.What == "" || plan.Gain <= 0 {
+			t.Errorf("Game.PlanMove() Nothing was worth suggesting in %s with nothing known", plan.Room)
+		}
+
+		if plan.Room == "living room" && (!plan.Passage || plan.Reach[0] != 1) {
+			t.Error("Game.PlanMove() The living room is always reachable through the secret passage.")
+		}
+	}
+}
+
+func TestPlanMoveOneTurn(t *testing.T) {
+	game := genPlanGame()
+
+	plans, err := game.PlanMove(context.Background(), "courtyard", cluedo.PlanOptions{Turns: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, plan := range plans {
+		if len(plan.Reach) != 1 || plan.Value != plan.Gain*plan.Reach[0] {
+			t.Errorf("Game.PlanMove() Looking 1 turn ahead %s should only count this turn", plan.Room)
+		}
+	}
+
+	if _, err := game.PlanMove(context.Background(), "attic", cluedo.PlanOptions{}); err == nil {
+		t.Error("Game.PlanMove() Didn't error for a room that isn't on the board.")
+	}
+}
+
+func TestPlanMoveLeavesGame(t *testing.T) {
+	game := genPlanGame()
+	q, err := game.QuestionFor(game.Card("plum"), game.Card("dagger"), game.Card("garage"), game.Me, game.Player("alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.SetAnswer(cluedo.UnknownAnswer)
+	if _, err := game.DoTurn(q); err != nil {
+		t.Fatal(err)
+	}
+
+	before := game.String()
+	clauses := len(game.Clauses())
+	if _, err := game.PlanMove(context.Background(), "garage", cluedo.PlanOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if game.String() != before || len(game.Clauses()) != clauses {
+		t.Error("Game.PlanMove() Trying out answers changed the game it was planning for.")
+	}
+}
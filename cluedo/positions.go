@@ -0,0 +1,101 @@
+package cluedo
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Suggesting a suspect moves their token into the suggested room and the
+// asker has to be in that room too, so every suggestion shows where some of
+// the pieces are.
+
+func (g *Game) moveTokens(r Question) {
+	g.tokenRooms[r.cards[0]] = r.cards[2]
+	g.playerRooms[r.asker] = r.cards[2]
+	// the asker's own token is in the room they're suggesting
+	if suspect, ok := g.suspects[r.asker]; ok {
+		g.tokenRooms[suspect] = r.cards[2]
+	}
+
+	// whoever plays the suspect is dragged along with their token
+	if p, ok := g.playing(r.cards[0]); ok {
+		g.playerRooms[p] = r.cards[2]
+	}
+}
+
+// TokenRoom is the room a suspect's token was last moved to.
+func (g *Game) TokenRoom(suspect string) (string, bool) {
+	id, err := g.LookupCard(suspect)
+	if err != nil {
+		return "", false
+	}
+	room, ok := g.tokenRooms[id]
+	if !ok {
+		return "", false
+	}
+	return g.CardByID(room).name, true
+}
+
+// MoveToken records a suspect's token being moved to room without a
+// suggestion, like when a player walks out of a room.
+func (g *Game) MoveToken(suspect string, room string) error {
+	id, err := g.LookupCard(suspect)
+	if err != nil {
+		return err
+	}
+	if !g.whoCategory.set().has(int(id)) {
+		return fmt.Errorf("`%s` isn't a who card", suspect)
+	}
+	roomID, err := g.LookupCard(room)
+	if err != nil {
+		return err
+	}
+	if !g.whereCategory.set().has(int(roomID)) {
+		return fmt.Errorf("`%s` isn't a where card", room)
+	}
+
+	g.tokenRooms[id] = roomID
+	return nil
+}
+
+// PlayerRoom is the room a player last made a suggestion in.
+func (g *Game) PlayerRoom(player *Player) (string, bool) {
+	p := g.k.playerIndex(player)
+	room, ok := g.playerRooms[PlayerID(p)]
+	if !ok {
+		return "", false
+	}
+	return g.CardByID(room).name, true
+}
+
+type RoomChance struct {
+	Room   string
+	Chance float64
+}
+
+// LikelyRooms is the chance of player being able to suggest each room next
+// turn, most likely first. It's based on the room they last suggested in.
+func (g *Game) LikelyRooms(player *Player) ([]RoomChance, error) {
+	room, ok := g.PlayerRoom(player)
+	if !ok {
+		return nil, fmt.Errorf("`%s` hasn't made a suggestion yet", player.name)
+	}
+
+	chances := []RoomChance{}
+	for _, target := range g.board.Rooms() {
+		steps, err := g.board.Distance(room, target)
+		if err != nil || target == room || steps == -1 {
+			continue
+		}
+		chances = append(chances, RoomChance{
+			Room:   target,
+			Chance: reachChance(steps, 1),
+		})
+	}
+
+	slices.SortStableFunc(chances, func(a, b RoomChance) int {
+		return cmp.Compare(b.Chance, a.Chance)
+	})
+	return chances, nil
+}
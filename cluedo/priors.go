@@ -0,0 +1,95 @@
+package cluedo
+
+import "math"
+
+// PlayerPrior is what's been learnt about how a player usually plays, from
+// earlier games. The zero value assumes nothing.
+type PlayerPrior struct {
+	// the share of the cards they suggest that come from their own hand
+	OwnCardRate float64
+}
+
+// rates this close to 0 or 1 would make a single suggestion all but certain
+const (
+	minOwnCardRate = 0.02
+	maxOwnCardRate = 0.98
+)
+
+// priorWeights is how much more likely each card is to be in each player's
+// hand given how often they've suggested it and how often they suggest their
+// own cards. A card suggested n times by a player with own card rate r, who
+// holds a share b of the cards, is (r(1-b) / b(1-r))^n times as likely to be
+// theirs as it would otherwise be. Players without a prior are given the
+// bluff rate. It's nil when there's nothing to weigh.
+func (g *Game) priorWeights(opts ProbabilityOptions) [][]float64 {
+	if len(opts.Priors) == 0 && opts.BluffRate == 0 {
+		return nil
+	}
+
+	suggested := make([][]int, len(g.players))
+	for p := range suggested {
+		suggested[p] = make([]int, len(g.cards))
+	}
+	for _, s := range g.suggestions() {
+		for _, c := range s.cards {
+			suggested[s.asker][c]++
+		}
+	}
+
+	var weights [][]float64
+	for p, player := range g.players {
+		prior, ok := opts.Priors[player.name]
+		if !ok {
+			prior.OwnCardRate = opts.BluffRate
+		}
+		if prior.OwnCardRate == 0 || player == g.Me {
+			continue
+		}
+		r := min(max(prior.OwnCardRate, minOwnCardRate), maxOwnCardRate)
+		b := float64(player.cardCount) / float64(len(g.cards))
+		if b == 0 {
+			continue
+		}
+		ratio := r * (1 - b) / (b * (1 - r))
+
+		for c, n := range suggested[p] {
+			if n == 0 {
+				continue
+			}
+			if weights == nil {
+				weights = make([][]float64, len(g.cards))
+				for i := range weights {
+					weights[i] = make([]float64, len(g.players))
+					for j := range weights[i] {
+						weights[i][j] = 1
+					}
+				}
+			}
+			weights[c][p] = math.Pow(ratio, float64(n))
+		}
+	}
+	return weights
+}
+
+// suggestions is every suggestion made so far. A suggestion answered by
+// several players is recorded as a turn for each of them, so turns in a row
+// with the same asker and cards are counted once until someone shows a card
+// or a player answers twice.
+func (g *Game) suggestions() []Question {
+	suggestions := []Question{}
+	// bit p is set if player p has answered the current suggestion
+	answered := uint64(0)
+	for i, t := range g.history {
+		q := t.question
+		if i > 0 {
+			last := g.history[i-1].question
+			if last.asker == q.asker && last.cards == q.cards && last.answer == NoAnswer && answered&(1<<q.answerer) == 0 {
+				answered |= 1 << q.answerer
+				continue
+			}
+		}
+		suggestions = append(suggestions, q)
+		answered = 1 << q.answerer
+	}
+	return suggestions
+}
@@ -0,0 +1,506 @@
+package cluedo
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+var (
+	ErrNoConsistentDeal = errors.New("no deal of the cards fits what's known, something was probably entered wrong")
+	ErrTooManyClauses   = errors.New("too many unresolved clauses to count deals")
+	ErrTooManyPlayers   = errors.New("too many players to count deals")
+)
+
+// Probabilities is how likely each card is to be in each player's hand or in
+// the envelope, counted over every deal of the cards that fits everything
+// known about the game.
+type Probabilities struct {
+	Players []string          `json:"players"`
+	Cards   []CardProbability `json:"cards"`
+
+	// total weight of all the consistent deals
+	Deals float64 `json:"deals"`
+}
+
+type CardProbability struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+
+	Envelope float64 `json:"envelope"`
+	// in the same order as Probabilities.Players
+	Owners []float64 `json:"owners"`
+}
+
+func (p Probabilities) Card(name string) (CardProbability, bool) {
+	for _, c := range p.Cards {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return CardProbability{}, false
+}
+
+func (p Probabilities) Owner(card string, player string) float64 {
+	c, ok := p.Card(card)
+	if !ok {
+		return 0
+	}
+	i := slices.Index(p.Players, player)
+	if i < 0 {
+		return 0
+	}
+	return c.Owners[i]
+}
+
+func (p Probabilities) InEnvelope(card string) float64 {
+	c, _ := p.Card(card)
+	return c.Envelope
+}
+
+type ProbabilityOptions struct {
+	// how many goroutines to count with. Defaults to GOMAXPROCS
+	Workers int
+
+	// called every time another possible envelope has been counted with an
+	// estimate from the envelopes counted so far
+	Progress func(Progress)
+
+	// how players usually play, by name. Deals that fit their habits are
+	// weighted up
+	Priors map[string]PlayerPrior
+	// the own card rate assumed for players without a prior, for tables
+	// where people bluff by suggesting their own cards. 0 treats their
+	// suggestions as telling nothing
+	BluffRate float64
+}
+
+type Progress struct {
+	Done  int
+	Total int
+
+	Partial Probabilities
+}
+
+// Probabilities counts every deal that fits what's known. The deals are split
+// up by what's in the envelope and counted in parallel. The count stops early
+// with the context's error if it's cancelled.
+func (g *Game) Probabilities(ctx context.Context, opts ProbabilityOptions) (Probabilities, error) {
+	problem, err := g.dealProblem()
+	if err != nil {
+		return Probabilities{}, err
+	}
+	problem.weights = g.priorWeights(opts)
+	return problem.count(ctx, opts)
+}
+
+// dealProblem is a copy of everything known about the game in a form that's
+// quick to count with and safe to share between goroutines.
+type dealProblem struct {
+	players    []string
+	cards      []string
+	categories []string
+	// the category index of each card
+	cardCategory []int
+
+	// -1 if the owner isn't known yet
+	owner []int
+	// bit p is set if player p could have the card
+	allowed []uint64
+	// whether the card could be in the envelope
+	envelopeAllowed []bool
+	// cards each player still has to be dealt
+	slots []int
+
+	clauses []dealClause
+
+	// weights[card][player] scales the deals giving card to player. nil if
+	// every deal counts the same
+	weights [][]float64
+}
+
+func (p dealProblem) weight(card, player int) float64 {
+	if p.weights == nil {
+		return 1
+	}
+	return p.weights[card][player]
+}
+
+type dealClause struct {
+	player int
+	cards  []int
+}
+
+func (g *Game) dealProblem() (dealProblem, error) {
+	p := dealProblem{}
+	if len(g.players) > maxDealPlayers {
+		return p, ErrTooManyPlayers
+	}
+
+	for _, player := range g.players {
+		p.players = append(p.players, player.name)
+		p.slots = append(p.slots, player.cardCount)
+	}
+
+	k := g.k
+	for ci, category := range g.categories() {
+		p.categories = append(p.categories, categoryNames[ci])
+		for _, c := range category.Cards {
+			p.cards = append(p.cards, c.name)
+			p.cardCategory = append(p.cardCategory, ci)
+
+			owner := k.owner(c.index)
+			if owner >= 0 {
+				p.slots[owner]--
+			}
+			allowed := uint64(0)
+			for pi := range g.players {
+				if k.possible[pi].has(c.index) {
+					allowed |= 1 << pi
+				}
+			}
+			p.owner = append(p.owner, owner)
+			p.allowed = append(p.allowed, allowed)
+			p.envelopeAllowed = append(p.envelopeAllowed, !c.IsFound() && !c.inEnvelopeRuledOut())
+		}
+	}
+
+	for _, clause := range k.clauses {
+		if clause.cards&k.owned[clause.player] != 0 {
+			continue
+		}
+		dc := dealClause{
+			player: clause.player,
+		}
+		for _, i := range clause.cards.indices() {
+			if p.owner[i] == -1 {
+				dc.cards = append(dc.cards, i)
+			}
+		}
+		p.clauses = append(p.clauses, dc)
+	}
+	if len(p.clauses) > 64 {
+		return p, ErrTooManyClauses
+	}
+
+	return p, nil
+}
+
+var categoryNames = []string{"who", "what", "where"}
+
+// envelopes lists every combination of one card per category that could be
+// in the envelope.
+func (p dealProblem) envelopes() [][]int {
+	envelopes := [][]int{{}}
+	for ci := range p.categories {
+		next := [][]int{}
+		for _, e := range envelopes {
+			for i := range p.cards {
+				if p.cardCategory[i] != ci || !p.envelopeAllowed[i] {
+					continue
+				}
+				next = append(next, append(slices.Clone(e), i))
+			}
+		}
+		envelopes = next
+	}
+	return envelopes
+}
+
+type envelopeCount struct {
+	envelope []int
+	total    float64
+	// owners[card][player] is the weight of deals giving card to player
+	owners [][]float64
+}
+
+func (p dealProblem) count(ctx context.Context, opts ProbabilityOptions) (Probabilities, error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	envelopes := p.envelopes()
+	jobs := make(chan []int)
+	results := make(chan envelopeCount)
+	errs := make(chan error, workers)
+
+	wg := sync.WaitGroup{}
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for envelope := range jobs {
+				result, err := p.countEnvelope(ctx, envelope)
+				if err != nil {
+					errs <- err
+					cancel()
+					return
+				}
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for _, e := range envelopes {
+			select {
+			case jobs <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	total := p.newTotals()
+	done := 0
+	for result := range results {
+		total.add(result)
+		done++
+		if opts.Progress != nil {
+			opts.Progress(Progress{
+				Done:    done,
+				Total:   len(envelopes),
+				Partial: p.probabilities(total),
+			})
+		}
+	}
+
+	select {
+	case err := <-errs:
+		return Probabilities{}, err
+	default:
+	}
+	if err := ctx.Err(); err != nil {
+		return Probabilities{}, err
+	}
+	if total.total == 0 {
+		return Probabilities{}, ErrNoConsistentDeal
+	}
+
+	return p.probabilities(total), nil
+}
+
+type dealTotals struct {
+	total    float64
+	envelope []float64
+	owners   [][]float64
+}
+
+func (p dealProblem) newTotals() *dealTotals {
+	t := &dealTotals{
+		envelope: make([]float64, len(p.cards)),
+		owners:   make([][]float64, len(p.cards)),
+	}
+	for i := range t.owners {
+		t.owners[i] = make([]float64, len(p.players))
+	}
+	return t
+}
+
+func (t *dealTotals) add(result envelopeCount) {
+	t.total += result.total
+	for _, c := range result.envelope {
+		t.envelope[c] += result.total
+	}
+	for c, owners := range result.owners {
+		for pi, w := range owners {
+			t.owners[c][pi] += w
+		}
+	}
+}
+
+func (p dealProblem) probabilities(t *dealTotals) Probabilities {
+	probs := Probabilities{
+		Players: slices.Clone(p.players),
+		Deals:   t.total,
+	}
+	for i, name := range p.cards {
+		c := CardProbability{
+			Name:     name,
+			Category: p.categories[p.cardCategory[i]],
+			Owners:   make([]float64, len(p.players)),
+		}
+		if t.total > 0 {
+			c.Envelope = t.envelope[i] / t.total
+			for pi := range p.players {
+				c.Owners[pi] = t.owners[i][pi] / t.total
+			}
+		}
+		probs.Cards = append(probs.Cards, c)
+	}
+	return probs
+}
+
+// dealState is the cards each player has left to be dealt and which clauses
+// have been satisfied so far.
+type dealState struct {
+	slots [maxDealPlayers]int8
+	mask  uint64
+}
+
+const maxDealPlayers = 8
+
+// how many new states are worked out between checks of the context
+const cancelCheckInterval = 1024
+
+// countEnvelope counts the deals with envelope in the envelope. It deals the
+// remaining cards one at a time, first working backwards to find how many
+// ways each state can be finished and then forwards to add up how often each
+// card goes to each player.
+func (p dealProblem) countEnvelope(ctx context.Context, envelope []int) (envelopeCount, error) {
+	result := envelopeCount{
+		envelope: envelope,
+		owners:   make([][]float64, len(p.cards)),
+	}
+	for i := range result.owners {
+		result.owners[i] = make([]float64, len(p.players))
+	}
+
+	unknown := []int{}
+	for i := range p.cards {
+		if p.owner[i] == -1 && !slices.Contains(envelope, i) {
+			unknown = append(unknown, i)
+		}
+	}
+
+	start := dealState{}
+	dealt := 0
+	for pi, s := range p.slots {
+		if s < 0 {
+			return result, nil
+		}
+		start.slots[pi] = int8(s)
+		dealt += s
+	}
+	if dealt != len(unknown) {
+		return result, nil
+	}
+
+	// clauses[i] is the mask of clauses satisfied by giving unknown[i] to
+	// each player
+	clauseMasks := make([][]uint64, len(unknown))
+	for i, c := range unknown {
+		clauseMasks[i] = make([]uint64, len(p.players))
+		for ci, clause := range p.clauses {
+			if slices.Contains(clause.cards, c) {
+				clauseMasks[i][clause.player] |= 1 << ci
+			}
+		}
+	}
+	allClauses := uint64(1)<<len(p.clauses) - 1
+
+	// completions[i][s] is how many ways the cards from unknown[i] onwards
+	// can be dealt starting from s
+	completions := make([]map[dealState]float64, len(unknown)+1)
+	for i := range completions {
+		completions[i] = map[dealState]float64{}
+	}
+	// there can be a state for every set of clauses so the context is
+	// checked every so often while they're worked out
+	var cancelled error
+	misses := 0
+	var finish func(i int, s dealState) float64
+	finish = func(i int, s dealState) float64 {
+		if cancelled != nil {
+			return 0
+		}
+		if i == len(unknown) {
+			if s.mask == allClauses {
+				return 1
+			}
+			return 0
+		}
+		if w, ok := completions[i][s]; ok {
+			return w
+		}
+		misses++
+		if misses%cancelCheckInterval == 0 {
+			if cancelled = ctx.Err(); cancelled != nil {
+				return 0
+			}
+		}
+
+		w := 0.0
+		for pi := range p.players {
+			if s.slots[pi] == 0 || p.allowed[unknown[i]]&(1<<pi) == 0 {
+				continue
+			}
+			next := s
+			next.slots[pi]--
+			next.mask |= clauseMasks[i][pi]
+			w += p.weight(unknown[i], pi) * finish(i+1, next)
+		}
+		completions[i][s] = w
+		return w
+	}
+
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	result.total = finish(0, start)
+	if cancelled != nil {
+		return result, cancelled
+	}
+	if result.total == 0 {
+		return result, nil
+	}
+
+	for c, owner := range p.owner {
+		if owner >= 0 {
+			result.owners[c][owner] = result.total
+		}
+	}
+
+	reached := map[dealState]float64{start: 1}
+	for i, c := range unknown {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		next := map[dealState]float64{}
+		for s, w := range reached {
+			misses++
+			if misses%cancelCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return result, err
+				}
+			}
+			for pi := range p.players {
+				if s.slots[pi] == 0 || p.allowed[c]&(1<<pi) == 0 {
+					continue
+				}
+				n := s
+				n.slots[pi]--
+				n.mask |= clauseMasks[i][pi]
+
+				ways := completions[i+1][n]
+				if i+1 == len(unknown) {
+					ways = finish(i+1, n)
+				}
+				if ways == 0 {
+					continue
+				}
+				dealt := w * p.weight(c, pi)
+				result.owners[c][pi] += dealt * ways
+				next[n] += dealt
+			}
+		}
+		reached = next
+	}
+
+	return result, nil
+}
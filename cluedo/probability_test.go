@@ -0,0 +1,306 @@
+package cluedo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// bruteForceProbabilities tries every envelope and every way of dealing the
+// unknown cards, checking each deal directly against the game. Each deal
+// counts as the product of weights for the cards in it, or 1 if weights is nil.
+func bruteForceProbabilities(g *Game, weights [][]float64) (total float64, envelope map[*Card]float64, owners map[*Card]map[*Player]float64) {
+	envelope = map[*Card]float64{}
+	owners = map[*Card]map[*Player]float64{}
+	for _, c := range g.GetAllCards() {
+		owners[c] = map[*Player]float64{}
+	}
+
+	unknown := []*Card{}
+	for _, c := range g.GetAllCards() {
+		if !c.IsFound() {
+			unknown = append(unknown, c)
+		}
+	}
+
+	deal := map[*Card]*Player{}
+	var try func(i int)
+	try = func(i int) {
+		if i == len(unknown) {
+			inEnvelope := []*Card{}
+			for _, category := range g.categories() {
+				count := 0
+				for _, c := range category.Cards {
+					if !c.IsFound() && deal[c] == nil {
+						count++
+						inEnvelope = append(inEnvelope, c)
+					}
+				}
+				if count != 1 {
+					return
+				}
+			}
+			for _, p := range g.players {
+				count := 0
+				for _, c := range g.GetAllCards() {
+					if c.Possessor() == p || (!c.IsFound() && deal[c] == p) {
+						count++
+					}
+				}
+				if count != p.cardCount {
+					return
+				}
+			}
+			for _, clause := range g.Clauses() {
+				if !slices.ContainsFunc(clause.cards, func(c *Card) bool { return deal[c] == clause.player || c.Possessor() == clause.player }) {
+					return
+				}
+			}
+
+			w := 1.0
+			for c, p := range deal {
+				if p != nil && weights != nil {
+					w *= weights[c.index][g.k.playerIndex(p)]
+				}
+			}
+
+			total += w
+			for _, c := range inEnvelope {
+				envelope[c] += w
+			}
+			for _, c := range g.GetAllCards() {
+				if c.IsFound() {
+					owners[c][c.Possessor()] += w
+				} else if deal[c] != nil {
+					owners[c][deal[c]] += w
+				}
+			}
+			return
+		}
+
+		c := unknown[i]
+		if !c.inEnvelopeRuledOut() {
+			deal[c] = nil
+			try(i + 1)
+		}
+		for _, p := range g.possibleOwners(c) {
+			deal[c] = p
+			try(i + 1)
+		}
+		delete(deal, c)
+	}
+	try(0)
+
+	return
+}
+
+func TestProbabilitiesMatchBruteForce(t *testing.T) {
+	tested := 0
+	for seed := range uint64(50) {
+		rng := rand.New(rand.NewPCG(seed, 3))
+		deal := dealRandomGame(rng, 3)
+
+		for range 60 {
+			unknown := 0
+			for _, c := range deal.game.GetAllCards() {
+				if !c.IsFound() {
+					unknown++
+				}
+			}
+			if unknown <= 10 {
+				break
+			}
+			for _, q := range deal.randomTurn(rng) {
+				deal.game.DoTurn(q)
+			}
+		}
+
+		total, envelope, owners := bruteForceProbabilities(deal.game, nil)
+		if total == 0 || total > 20000 {
+			continue
+		}
+		tested++
+
+		probs, err := deal.game.Probabilities(context.Background(), ProbabilityOptions{})
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if probs.Deals != total {
+			t.Fatalf("seed %d: counted %v deals but there are %v", seed, probs.Deals, total)
+		}
+		checkProbabilities(t, seed, deal.game, probs, envelope, owners, total)
+
+		// the first opponent mostly suggests their own cards
+		priors := map[string]PlayerPrior{deal.game.players[1].name: {OwnCardRate: 0.6}}
+		total, envelope, owners = bruteForceProbabilities(deal.game, deal.game.priorWeights(ProbabilityOptions{Priors: priors}))
+		probs, err = deal.game.Probabilities(context.Background(), ProbabilityOptions{Priors: priors})
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if math.Abs(probs.Deals-total) > 1e-9*total {
+			t.Fatalf("seed %d: counted %v weighted deals but there are %v", seed, probs.Deals, total)
+		}
+		checkProbabilities(t, seed, deal.game, probs, envelope, owners, total)
+	}
+
+	if tested < 10 {
+		t.Errorf("only %d random games were small enough to check by brute force", tested)
+	}
+}
+
+// alice suggests plum, rope and kitchen twice and bob and we pass both times.
+func genRepeatedSuggestionGame(t *testing.T) *Game {
+	g := NewDefaultGame(NewPlayer("alice", 9), NewPlayer("bob", 9))
+	for range 2 {
+		for _, answerer := range []PlayerID{2, 0} {
+			q := g.NewQuestion(g.Card("plum").ID(), g.Card("rope").ID(), g.Card("kitchen").ID(), 1, answerer)
+			q.SetAnswer(NoAnswer)
+			if _, err := g.DoTurn(q); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return &g
+}
+
+func TestSuggestionsKeepsRepeats(t *testing.T) {
+	g := genRepeatedSuggestionGame(t)
+	if n := len(g.suggestions()); n != 2 {
+		t.Errorf("Game.suggestions() The same suggestion made twice was counted as %d suggestions", n)
+	}
+}
+
+func TestPriorWeights(t *testing.T) {
+	g := genRepeatedSuggestionGame(t)
+
+	// alice holds 9 of the 21 cards so with an own card rate of 0.6 each
+	// suggestion makes a card 0.6*(12/21) / ((9/21)*0.4) = 2 times as likely
+	// to be hers
+	weights := g.priorWeights(ProbabilityOptions{Priors: map[string]PlayerPrior{
+		"alice": {OwnCardRate: 0.6},
+		MeIdent: {OwnCardRate: 0.6},
+	}})
+	for c, card := range g.cards {
+		for p, player := range g.players {
+			want := 1.0
+			if player.name == "alice" && slices.Contains([]string{"plum", "rope", "kitchen"}, card.name) {
+				want = 4
+			}
+			if math.Abs(weights[c][p]-want) > 1e-9 {
+				t.Errorf("Game.priorWeights() %s having %s was weighted %v instead of %v", player.name, card.name, weights[c][p], want)
+			}
+		}
+	}
+
+	if g.priorWeights(ProbabilityOptions{}) != nil {
+		t.Error("Game.priorWeights() Gave weights without any priors or bluff rate.")
+	}
+}
+
+func checkProbabilities(t *testing.T, seed uint64, g *Game, probs Probabilities, envelope map[*Card]float64, owners map[*Card]map[*Player]float64, total float64) {
+	t.Helper()
+	for _, c := range g.GetAllCards() {
+		if got, want := probs.InEnvelope(c.name), envelope[c]/total; math.Abs(got-want) > 1e-9 {
+			t.Errorf("seed %d: %s was in the envelope with probability %v but should've been %v", seed, c.name, got, want)
+		}
+		for _, p := range g.players {
+			if got, want := probs.Owner(c.name, p.name), owners[c][p]/total; math.Abs(got-want) > 1e-9 {
+				t.Errorf("seed %d: %s had %s with probability %v but should've been %v", seed, p.name, c.name, got, want)
+			}
+		}
+	}
+}
+
+func TestProbabilitiesProgress(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 3))
+	deal := dealRandomGame(rng, 3)
+
+	updates := 0
+	last := Progress{}
+	probs, err := deal.game.Probabilities(context.Background(), ProbabilityOptions{
+		Workers: 4,
+		Progress: func(p Progress) {
+			updates++
+			last = p
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updates == 0 || last.Done != last.Total || updates != last.Total {
+		t.Errorf("Game.Probabilities() Expected progress for all %d envelopes but got %d updates", last.Total, updates)
+	}
+	if last.Partial.Deals != probs.Deals {
+		t.Error("Game.Probabilities() The last progress update didn't match the final result")
+	}
+}
+
+func TestProbabilitiesCancelled(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 5))
+	deal := dealRandomGame(rng, 5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := deal.game.Probabilities(ctx, ProbabilityOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Game.Probabilities() Expected the count to be cancelled but got %v", err)
+	}
+}
+
+// countdownContext is cancelled once Err has been called calls times.
+type countdownContext struct {
+	context.Context
+	calls int
+}
+
+func (c *countdownContext) Err() error {
+	c.calls--
+	if c.calls < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestProbabilitiesCancelledDuringCount(t *testing.T) {
+	// lots of clauses over undealt cards give far too many states to work
+	// out before noticing the count was cancelled
+	rng := rand.New(rand.NewPCG(7, 5))
+	p := dealProblem{
+		players:    []string{"a", "b", "c", "d", "e", "f"},
+		categories: []string{"everything"},
+		slots:      []int{3, 3, 3, 3, 3, 3},
+	}
+	for i := range 19 {
+		p.cards = append(p.cards, fmt.Sprint(i))
+		p.cardCategory = append(p.cardCategory, 0)
+		p.owner = append(p.owner, -1)
+		p.allowed = append(p.allowed, 1<<len(p.players)-1)
+		p.envelopeAllowed = append(p.envelopeAllowed, i == 0)
+	}
+	for i := range 20 {
+		p.clauses = append(p.clauses, dealClause{
+			player: i % len(p.players),
+			cards:  []int{1 + rng.IntN(18), 1 + rng.IntN(18)},
+		})
+	}
+
+	// the first check is before counting starts so this cancels partway
+	// through working out the completions
+	ctx := &countdownContext{Context: context.Background(), calls: 1}
+	result, err := p.countEnvelope(ctx, []int{0})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("dealProblem.countEnvelope() Expected the count to be cancelled but got %v", err)
+	}
+	if result.total != 0 {
+		t.Error("dealProblem.countEnvelope() Finished working out the completions before noticing it was cancelled")
+	}
+	if ctx.calls < -1 {
+		t.Errorf("dealProblem.countEnvelope() Kept checking the context %d more times after it was cancelled", -ctx.calls-1)
+	}
+}
@@ -10,31 +10,35 @@ const (
 	NoAnswer
 )
 
+// Question is one player asking another about a who, what and where card.
+// It's made by a game and everything in it is an ID in that game, so it can
+// be played in the game or any of its clones.
 type Question struct {
-	whoPart   *Card
-	whatPart  *Card
-	wherePart *Card
-
-	asker    *Player
-	answerer *Player
+	// who, what and where
+	cards    [3]CardID
+	asker    PlayerID
+	answerer PlayerID
 
 	answer Answer
 }
 
-func NewQuestion(who, what, where *Card, asker, answerer *Player) Question {
-	return Question{
-		whoPart:   who,
-		whatPart:  what,
-		wherePart: where,
-		asker:     asker,
-		answerer:  answerer,
-	}
-}
-
 func (q *Question) SetAnswer(a Answer) {
 	q.answer = a
 }
 
+// shown is the card that was shown, if we know it.
+func (q Question) shown() (CardID, bool) {
+	switch q.answer {
+	case WhoAnswer:
+		return q.cards[0], true
+	case WhatAnswer:
+		return q.cards[1], true
+	case WhereAnswer:
+		return q.cards[2], true
+	}
+	return 0, false
+}
+
 type Player struct {
 	name      string
 	cardCount int
@@ -46,3 +50,11 @@ func NewPlayer(name string, count int) *Player {
 		cardCount: count,
 	}
 }
+
+func (p *Player) Name() string {
+	return p.name
+}
+
+func (p *Player) CardCount() int {
+	return p.cardCount
+}
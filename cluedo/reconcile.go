@@ -0,0 +1,331 @@
+package cluedo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Reconciliation compares a game to the hands everyone revealed at the end.
+type Reconciliation struct {
+	// deductions that disagree with the reveal, which usually means a turn
+	// was entered wrong
+	Mistakes []Mistake
+	// when the location of each card could first have been worked out and
+	// when it actually was, in game order
+	Cards []CardTiming
+
+	// mean squared error of the final probabilities against where the cards
+	// really were, from 0 for perfect up to 2
+	Brier float64
+	// why the probabilities couldn't be counted, if they couldn't. Knowable
+	// is only set up to the turn it happened on
+	ProbabilityErr error
+}
+
+type Mistake struct {
+	// the first turn the mistake showed up on. 0 is the starting hand
+	Turn    int
+	Card    string
+	Player  string
+	Problem string
+}
+
+func (m Mistake) String() string {
+	return fmt.Sprintf("turn %d: %s", m.Turn, m.Problem)
+}
+
+type CardTiming struct {
+	Card string
+	// the player that had the card or EnvelopeIdent
+	Owner string
+
+	// the first turn that everything entered so far only left one place for
+	// the card to be, or -1 if that never happened
+	Knowable int
+	// the first turn the game worked it out, or -1 if it never did
+	Learnt int
+
+	// the final chance the probabilities gave to where the card really was
+	Chance float64
+}
+
+// the truth uses -1 for the envelope and player indices for everyone else
+const truthEnvelope = -1
+
+// Reconcile checks the game against the cards everyone revealed at the end.
+// actualHands has each player's cards by name, ours can be left out, and
+// envelope has the three murder cards. The game is played again from the
+// start to find when each mistake was made and when each card could have
+// been placed. Facts entered straight into the game rather than as turns
+// are checked as of the last turn. Counting the probabilities stops with the
+// context's error if it's cancelled.
+func (g *Game) Reconcile(ctx context.Context, actualHands map[string][]string, envelope []string) (Reconciliation, error) {
+	r := Reconciliation{
+		Mistakes: []Mistake{},
+		Cards:    []CardTiming{},
+	}
+
+	truth, err := g.truth(actualHands, envelope)
+	if err != nil {
+		return r, err
+	}
+
+	for p, player := range g.players {
+		held := 0
+		for _, t := range truth {
+			if t == p {
+				held++
+			}
+		}
+		if held != player.cardCount {
+			r.Mistakes = append(r.Mistakes, Mistake{
+				Player:  player.name,
+				Problem: fmt.Sprintf("%s was entered as having %d cards but had %d", player.name, player.cardCount, held),
+			})
+		}
+	}
+
+	for i, c := range g.cards {
+		r.Cards = append(r.Cards, CardTiming{
+			Card:     c.name,
+			Owner:    g.locationName(truth[i]),
+			Knowable: -1,
+			Learnt:   -1,
+		})
+	}
+
+	seen := map[string]bool{}
+	var probs Probabilities
+	check := func(game *Game, turn int) {
+		for _, m := range game.contradictions(truth) {
+			if !seen[m.Problem] {
+				seen[m.Problem] = true
+				m.Turn = turn
+				r.Mistakes = append(r.Mistakes, m)
+			}
+		}
+		for i := range r.Cards {
+			if r.Cards[i].Learnt == -1 && game.placed(i, truth[i]) {
+				r.Cards[i].Learnt = turn
+			}
+		}
+
+		if r.ProbabilityErr != nil || !slices.ContainsFunc(r.Cards, func(c CardTiming) bool { return c.Knowable == -1 }) {
+			return
+		}
+		probs, r.ProbabilityErr = game.Probabilities(ctx, ProbabilityOptions{})
+		if r.ProbabilityErr != nil {
+			return
+		}
+		for i := range r.Cards {
+			if r.Cards[i].Knowable == -1 && truthChance(probs, i, truth[i]) > 1-1e-9 {
+				r.Cards[i].Knowable = turn
+			}
+		}
+	}
+
+	replay := g.replayStart()
+	check(replay, 0)
+	for _, record := range g.history {
+		q := record.question
+		question := replay.NewQuestion(q.cards[0], q.cards[1], q.cards[2], q.asker, q.answerer)
+		question.SetAnswer(q.answer)
+		replay.DoTurn(question)
+		check(replay, record.turn)
+	}
+	// the replay only has the turns, anything added by hand is only in the
+	// game itself
+	check(g, g.turn)
+	if err := ctx.Err(); err != nil {
+		return r, err
+	}
+
+	// the knowable check stops counting once everything is placed so the
+	// final probabilities have to be counted separately
+	final, err := g.Probabilities(ctx, ProbabilityOptions{})
+	if err != nil {
+		r.ProbabilityErr = err
+		return r, nil
+	}
+	for i := range r.Cards {
+		r.Cards[i].Chance = truthChance(final, i, truth[i])
+
+		locations := append([]float64{final.Cards[i].Envelope}, final.Cards[i].Owners...)
+		for l, chance := range locations {
+			if l-1 == truth[i] {
+				chance--
+			}
+			r.Brier += chance * chance
+		}
+	}
+	r.Brier /= float64(len(r.Cards))
+
+	return r, nil
+}
+
+// truth works out where every card really was, checking the reveal accounts
+// for every card exactly once.
+func (g *Game) truth(actualHands map[string][]string, envelope []string) ([]int, error) {
+	truth := make([]int, len(g.cards))
+	given := make([]bool, len(g.cards))
+
+	place := func(name string, location int) error {
+		id, err := g.LookupCard(name)
+		if err != nil {
+			return err
+		}
+		if given[id] {
+			return fmt.Errorf("`%s` was revealed twice", name)
+		}
+		given[id] = true
+		truth[id] = location
+		return nil
+	}
+
+	for name, hand := range actualHands {
+		p, err := g.LookupPlayer(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range hand {
+			if err := place(c, int(p)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if _, ok := actualHands[MeIdent]; !ok {
+		me := g.k.playerIndex(g.Me)
+		for _, c := range g.hand.indices() {
+			if err := place(g.cards[c].name, me); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, player := range g.players {
+		if _, ok := actualHands[player.name]; !ok && player != g.Me {
+			return nil, fmt.Errorf("%s's hand wasn't given", player.name)
+		}
+	}
+
+	for _, c := range envelope {
+		if err := place(c, truthEnvelope); err != nil {
+			return nil, err
+		}
+	}
+	missing := []string{}
+	for i, ok := range given {
+		if !ok {
+			missing = append(missing, g.cards[i].name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("nobody revealed %s", strings.Join(missing, ", "))
+	}
+
+	for i, category := range g.categories() {
+		n := 0
+		for _, c := range category.set().indices() {
+			if truth[c] == truthEnvelope && given[c] {
+				n++
+			}
+		}
+		if n != 1 {
+			return nil, fmt.Errorf("the envelope needs one %s card but had %d", categoryNames[i], n)
+		}
+	}
+
+	return truth, nil
+}
+
+func (g *Game) locationName(location int) string {
+	if location == truthEnvelope {
+		return EnvelopeIdent
+	}
+	return g.players[location].name
+}
+
+// replayStart is a new game with the same players and starting hand as this
+// one but none of the turns.
+func (g *Game) replayStart() *Game {
+	others := []*Player{}
+	for _, p := range g.players {
+		if p != g.Me {
+			others = append(others, NewPlayer(p.name, p.cardCount))
+		}
+	}
+	replay := NewGameWithBoard(g.board, others...)
+
+	if g.hand != 0 {
+		hand := []*Card{}
+		for _, c := range g.hand.indices() {
+			hand = append(hand, replay.cards[c])
+		}
+		replay.AddStartingHand(hand)
+	}
+	return &replay
+}
+
+// placed is whether the game knows card is at location.
+func (g *Game) placed(card int, location int) bool {
+	if location == truthEnvelope {
+		return g.k.murder.has(card)
+	}
+	return g.k.owned[location].has(card)
+}
+
+func truthChance(probs Probabilities, card int, location int) float64 {
+	if location == truthEnvelope {
+		return probs.Cards[card].Envelope
+	}
+	return probs.Cards[card].Owners[location]
+}
+
+// contradictions lists everything the game believes that the truth says is
+// wrong.
+func (g *Game) contradictions(truth []int) []Mistake {
+	k := g.k
+	mistakes := []Mistake{}
+	add := func(card int, player string, format string, args ...any) {
+		mistakes = append(mistakes, Mistake{
+			Card:    g.cards[card].name,
+			Player:  player,
+			Problem: fmt.Sprintf(format, args...),
+		})
+	}
+
+	for i, c := range g.cards {
+		t := truth[i]
+		where := g.locationName(t)
+
+		if owner := k.owner(i); owner >= 0 && owner != t {
+			add(i, g.players[owner].name, "%s was marked as %s's but it was %s", c.name, g.players[owner].name, where)
+		} else if k.found.has(i) && t == truthEnvelope {
+			add(i, "", "%s was marked as in someone's hand but it was in the envelope", c.name)
+		}
+		if k.murder.has(i) && t != truthEnvelope {
+			add(i, where, "%s was marked as a murder card but it was %s's", c.name, where)
+		}
+		if t == truthEnvelope && !k.envelopeCandidates.has(i) {
+			add(i, "", "%s was ruled out of the envelope but it was in it", c.name)
+		}
+		if t != truthEnvelope && !k.possible[t].has(i) {
+			add(i, where, "%s was marked as not having %s but did", where, c.name)
+		}
+	}
+
+	for _, clause := range k.clauses {
+		if !slices.ContainsFunc(clause.cards.indices(), func(c int) bool { return truth[c] == clause.player }) {
+			names := []string{}
+			for _, c := range clause.cards.indices() {
+				names = append(names, g.cards[c].name)
+			}
+			player := g.players[clause.player].name
+			add(clause.cards.first(), player, "%s was marked as having one of %s but had none of them", player, strings.Join(names, ", "))
+		}
+	}
+
+	return mistakes
+}
@@ -0,0 +1,143 @@
+package cluedo
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// reveal gives everyone's hands and the envelope as Reconcile takes them.
+func (d hiddenDeal) reveal() (map[string][]string, []string) {
+	hands := map[string][]string{}
+	for c, p := range d.owner {
+		hands[p.name] = append(hands[p.name], c.name)
+	}
+	envelope := []string{}
+	for _, c := range d.envelope {
+		envelope = append(envelope, c.name)
+	}
+	return hands, envelope
+}
+
+func TestReconcileHonestGame(t *testing.T) {
+	for seed := range uint64(5) {
+		rng := rand.New(rand.NewPCG(seed, 3))
+		deal := dealRandomGame(rng, 3)
+		for range 25 {
+			for _, q := range deal.randomTurn(rng) {
+				deal.game.DoTurn(q)
+			}
+		}
+
+		hands, envelope := deal.reveal()
+		r, err := deal.game.Reconcile(context.Background(), hands, envelope)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(r.Mistakes) != 0 || r.ProbabilityErr != nil {
+			t.Fatalf("seed %d: Game.Reconcile() Found mistakes in an honest game: %v %v", seed, r.Mistakes, r.ProbabilityErr)
+		}
+		if r.Brier < 0 || r.Brier > 2 {
+			t.Errorf("seed %d: Game.Reconcile() Brier score %v is out of range", seed, r.Brier)
+		}
+
+		for _, c := range r.Cards {
+			if c.Learnt != -1 && (c.Knowable == -1 || c.Knowable > c.Learnt) {
+				t.Errorf("seed %d: Game.Reconcile() %s was learnt on turn %d before it could be known on turn %d", seed, c.Card, c.Learnt, c.Knowable)
+			}
+			if c.Chance <= 0 {
+				t.Errorf("seed %d: Game.Reconcile() %s being %s's was given no chance", seed, c.Card, c.Owner)
+			}
+		}
+	}
+}
+
+func TestReconcileFindsMistake(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 3))
+	deal := dealRandomGame(rng, 3)
+	g := deal.game
+
+	for range 3 {
+		for _, q := range deal.randomTurn(rng) {
+			g.DoTurn(q)
+		}
+	}
+
+	// p1 wrongly entered as passing on a card they have
+	held := slices.IndexFunc(g.cards, func(c *Card) bool { return deal.owner[c] == g.players[1] && g.categories()[0].set().has(c.index) })
+	q := g.NewQuestion(CardID(held), g.whatCategory.Cards[0].ID(), g.whereCategory.Cards[0].ID(), 0, 1)
+	q.SetAnswer(NoAnswer)
+	g.DoTurn(q)
+	lieTurn := g.turn
+
+	hands, envelope := deal.reveal()
+	r, err := g.Reconcile(context.Background(), hands, envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "p1 was marked as not having " + g.cards[held].name + " but did"
+	i := slices.IndexFunc(r.Mistakes, func(m Mistake) bool { return m.Problem == want })
+	if i < 0 {
+		t.Fatalf("Game.Reconcile() Didn't find the wrong pass, only %v", r.Mistakes)
+	}
+	if r.Mistakes[i].Turn != lieTurn {
+		t.Errorf("Game.Reconcile() The wrong pass was on turn %d but was reported on turn %d", lieTurn, r.Mistakes[i].Turn)
+	}
+}
+
+func TestReconcileChecksReveal(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	deal := dealRandomGame(rng, 2)
+
+	hands, envelope := deal.reveal()
+	if _, err := deal.game.Reconcile(context.Background(), hands, envelope[:2]); err == nil || !strings.Contains(err.Error(), "nobody revealed") {
+		t.Errorf("Game.Reconcile() Expected a missing card error but got %v", err)
+	}
+	if _, err := deal.game.Reconcile(context.Background(), hands, append(envelope, envelope[0])); err == nil {
+		t.Error("Game.Reconcile() Allowed a card to be revealed twice.")
+	}
+
+	delete(hands, "p1")
+	if _, err := deal.game.Reconcile(context.Background(), hands, envelope); err == nil {
+		t.Error("Game.Reconcile() Allowed p1's hand to be left out.")
+	}
+}
+
+func TestReconcileChecksManualFacts(t *testing.T) {
+	rng := rand.New(rand.NewPCG(4, 3))
+	deal := dealRandomGame(rng, 3)
+	g := deal.game
+	for range 3 {
+		for _, q := range deal.randomTurn(rng) {
+			g.DoTurn(q)
+		}
+	}
+
+	// p2 wrongly ruled out of a card they have, outside of any turn
+	held := slices.IndexFunc(g.cards, func(c *Card) bool { return deal.owner[c] == g.players[2] && !c.IsFound() })
+	g.cards[held].AddNonPossessor(g.players[2])
+
+	hands, envelope := deal.reveal()
+	r, err := g.Reconcile(context.Background(), hands, envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "p2 was marked as not having " + g.cards[held].name + " but did"
+	i := slices.IndexFunc(r.Mistakes, func(m Mistake) bool { return m.Problem == want })
+	if i < 0 {
+		t.Fatalf("Game.Reconcile() Didn't find the fact added by hand, only %v", r.Mistakes)
+	}
+	if r.Mistakes[i].Turn != g.turn {
+		t.Errorf("Game.Reconcile() The fact added by hand was reported on turn %d instead of the last turn %d", r.Mistakes[i].Turn, g.turn)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := g.Reconcile(ctx, hands, envelope); !errors.Is(err, context.Canceled) {
+		t.Errorf("Game.Reconcile() Expected to be cancelled but got %v", err)
+	}
+}
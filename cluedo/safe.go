@@ -0,0 +1,84 @@
+package cluedo
+
+import "sync"
+
+// SafeGame lets a game be shared between goroutines. Turns are played one at
+// a time and readers always see the game between turns, never part way
+// through one.
+type SafeGame struct {
+	mu   sync.RWMutex
+	game *Game
+}
+
+func NewSafeGame(game *Game) *SafeGame {
+	return &SafeGame{
+		game: game,
+	}
+}
+
+func (s *SafeGame) DoTurn(question Question) (TurnResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.game.DoTurn(question)
+}
+
+func (s *SafeGame) AddStartingHand(hand []*Card) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.game.AddStartingHand(hand)
+}
+
+// Write runs f with sole access to the game for changes that don't have
+// their own method.
+func (s *SafeGame) Write(f func(g *Game)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f(s.game)
+}
+
+// Read runs f with the game locked against changes. f mustn't change the
+// game or keep hold of it after returning.
+func (s *SafeGame) Read(f func(g *Game)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	f(s.game)
+}
+
+func (s *SafeGame) Snapshot() Snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.game.Snapshot()
+}
+
+func (s *SafeGame) Clone() Game {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.game.Clone()
+}
+
+func (s *SafeGame) WhatIf(question Question, answer Answer) (TurnResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.game.WhatIf(question, answer)
+}
+
+func (s *SafeGame) ValidateQuestion(question Question) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.game.ValidateQuestion(question)
+}
+
+func (s *SafeGame) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.game.String()
+}
@@ -0,0 +1,86 @@
+package cluedo_test
+
+import (
+	"math/rand/v2"
+	"sync"
+	"testing"
+
+	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
+)
+
+// TestSafeGameStress plays turns and reads the game from many goroutines at
+// once. It's mostly useful under `go test -race`.
+func TestSafeGameStress(t *testing.T) {
+	alice := cluedo.NewPlayer("alice", 6)
+	bob := cluedo.NewPlayer("bob", 6)
+	charlie := cluedo.NewPlayer("charlie", 6)
+	game := cluedo.NewDefaultGame(alice, bob, charlie)
+	safe := cluedo.NewSafeGame(&game)
+
+	who := []string{"green", "mustard", "peacock", "plum", "scarlet", "white"}
+	what := []string{"wrench", "candlestick", "dagger", "pistol", "lead pipe", "rope"}
+	where := []string{"bathroom", "study", "dining room", "games room", "garage", "bedroom", "living room", "kitchen", "courtyard"}
+	players := []*cluedo.Player{alice, bob, charlie}
+
+	// questions are made of IDs so they're looked up before sharing the game
+	ids := func(names []string) []cluedo.CardID {
+		cards := []cluedo.CardID{}
+		for _, name := range names {
+			id, err := game.LookupCard(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cards = append(cards, id)
+		}
+		return cards
+	}
+	whoIDs, whatIDs, whereIDs := ids(who), ids(what), ids(where)
+	playerIDs := map[*cluedo.Player]cluedo.PlayerID{}
+	for _, p := range append(players, game.Me) {
+		id, err := game.LookupPlayer(p.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		playerIDs[p] = id
+	}
+
+	wg := sync.WaitGroup{}
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := rand.New(rand.NewPCG(uint64(i), 0))
+
+			for range 50 {
+				asker := players[rng.IntN(len(players))]
+				answerer := players[rng.IntN(len(players))]
+				if asker == answerer {
+					answerer = game.Me
+				}
+				question := game.NewQuestion(
+					whoIDs[rng.IntN(len(whoIDs))],
+					whatIDs[rng.IntN(len(whatIDs))],
+					whereIDs[rng.IntN(len(whereIDs))],
+					playerIDs[asker],
+					playerIDs[answerer],
+				)
+
+				switch i % 4 {
+				case 0:
+					// only unknown answers so the random turns never contradict
+					question.SetAnswer(cluedo.UnknownAnswer)
+					safe.DoTurn(question)
+				case 1:
+					if _, err := safe.Snapshot().JSON(); err != nil {
+						t.Error(err)
+					}
+				case 2:
+					_ = safe.String()
+				case 3:
+					safe.WhatIf(question, cluedo.UnknownAnswer)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
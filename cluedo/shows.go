@@ -0,0 +1,92 @@
+package cluedo
+
+// A show is a player being shown a card for their suggestion. When it's
+// between two other players we don't see the card, but the asker does, so we
+// keep track of what each player could have learnt.
+
+// cardShow is a card from cards being shown to asker by answerer. Both are
+// indices into knowledge.players.
+type cardShow struct {
+	asker    int
+	answerer int
+	cards    cardSet
+}
+
+func (g *Game) recordShow(r Question) {
+	asker := int(r.asker)
+	if card, ok := r.shown(); ok {
+		g.k.seen[asker] = g.k.seen[asker].with(int(card))
+		return
+	}
+	if r.answer != UnknownAnswer {
+		return
+	}
+
+	show := cardShow{
+		asker:    asker,
+		answerer: int(r.answerer),
+	}
+	for _, c := range r.cards {
+		show.cards = show.cards.with(int(c))
+	}
+	g.k.shows = append(g.k.shows, show)
+}
+
+// UpdateShows works out which card was shown in a show we didn't see once
+// the answerer can only have had one of them. Everyone has also seen the
+// cards in their own hand.
+func (g *Game) UpdateShows() {
+	k := g.k
+	for p := range k.seen {
+		k.seen[p] |= k.owned[p]
+	}
+
+	remaining := []cardShow{}
+	for _, show := range k.shows {
+		candidates := show.cards & k.possible[show.answerer]
+		switch candidates.len() {
+		case 0:
+			// the answerer can't have shown anything so something was
+			// entered wrong
+			continue
+		case 1:
+			k.seen[show.asker] |= candidates
+		default:
+			remaining = append(remaining, show)
+		}
+	}
+	k.shows = remaining
+}
+
+// SeenBy lists the cards player is known to have seen.
+func (g *Game) SeenBy(player *Player) []*Card {
+	p := g.k.playerIndex(player)
+	if p < 0 {
+		return []*Card{}
+	}
+
+	seen := []*Card{}
+	for _, i := range g.k.seen[p].indices() {
+		seen = append(seen, g.cards[i])
+	}
+	return seen
+}
+
+// ShownTo lists the shows player has had where it isn't known which card they
+// saw. Each clause is over the answerer and the cards they could have shown.
+func (g *Game) ShownTo(player *Player) []Clause {
+	p := g.k.playerIndex(player)
+
+	shows := []Clause{}
+	for _, show := range g.k.shows {
+		if show.asker != p {
+			continue
+		}
+		clause := g.clause(cardClause{
+			player: show.answerer,
+			cards:  show.cards & g.k.possible[show.answerer],
+		})
+		shows = append(shows, clause)
+	}
+	return shows
+}
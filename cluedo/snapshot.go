@@ -0,0 +1,167 @@
+package cluedo
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+type CardStatus string
+
+const (
+	StatusUnknown CardStatus = "unknown"
+	// someone has the card but it isn't known who
+	StatusHeld   CardStatus = "held"
+	StatusFound  CardStatus = "found"
+	StatusMurder CardStatus = "murder"
+)
+
+// Snapshot is a copy of everything the game has deduced so far. It shares no
+// memory with the game so it stays the same however the game changes later.
+type Snapshot struct {
+	Players     []string             `json:"players"`
+	Categories  []CategorySnapshot   `json:"categories"`
+	Constraints []ConstraintSnapshot `json:"constraints"`
+	Tokens      []TokenSnapshot      `json:"tokens"`
+}
+
+type CategorySnapshot struct {
+	Name     string         `json:"name"`
+	Solved   bool           `json:"solved"`
+	Solution string         `json:"solution,omitempty"`
+	Cards    []CardSnapshot `json:"cards"`
+}
+
+type CardSnapshot struct {
+	Name       string     `json:"name"`
+	Status     CardStatus `json:"status"`
+	Owner      string     `json:"owner,omitempty"`
+	Eliminated []string   `json:"eliminated"`
+}
+
+// TokenSnapshot is the room a suspect's token was last moved to.
+type TokenSnapshot struct {
+	Suspect string `json:"suspect"`
+	Room    string `json:"room"`
+}
+
+// ConstraintSnapshot is a pending "player has at least one of these cards"
+// fact that hasn't been resolved yet.
+type ConstraintSnapshot struct {
+	Player string   `json:"player"`
+	Cards  []string `json:"cards"`
+}
+
+func (g *Game) Snapshot() Snapshot {
+	s := Snapshot{
+		Players:     []string{},
+		Constraints: []ConstraintSnapshot{},
+		Tokens:      []TokenSnapshot{},
+	}
+	for _, p := range g.players {
+		s.Players = append(s.Players, p.name)
+	}
+
+	s.Categories = []CategorySnapshot{
+		g.snapshotCategory("who", g.whoCategory),
+		g.snapshotCategory("what", g.whatCategory),
+		g.snapshotCategory("where", g.whereCategory),
+	}
+
+	addConstraint := func(player *Player, cards ...*Card) {
+		names := []string{}
+		for _, c := range cards {
+			names = append(names, c.name)
+		}
+		slices.Sort(names)
+
+		constraint := ConstraintSnapshot{
+			Player: player.name,
+			Cards:  names,
+		}
+		if !slices.ContainsFunc(s.Constraints, constraint.Equals) {
+			s.Constraints = append(s.Constraints, constraint)
+		}
+	}
+	for _, c := range g.Clauses() {
+		addConstraint(c.player, c.cards...)
+	}
+	slices.SortFunc(s.Constraints, func(a, b ConstraintSnapshot) int {
+		if n := strings.Compare(a.Player, b.Player); n != 0 {
+			return n
+		}
+		return slices.Compare(a.Cards, b.Cards)
+	})
+
+	for _, c := range g.whoCategory.Cards {
+		if room, ok := g.TokenRoom(c.name); ok {
+			s.Tokens = append(s.Tokens, TokenSnapshot{
+				Suspect: c.name,
+				Room:    room,
+			})
+		}
+	}
+
+	return s
+}
+
+func (g *Game) snapshotCategory(name string, category CardCategory) CategorySnapshot {
+	cs := CategorySnapshot{
+		Name:  name,
+		Cards: []CardSnapshot{},
+	}
+
+	for _, c := range category.Cards {
+		card := CardSnapshot{
+			Name:       c.name,
+			Status:     StatusUnknown,
+			Eliminated: []string{},
+		}
+
+		if c.IsFound() {
+			card.Status = StatusFound
+			if possessor := c.Possessor(); possessor != nil {
+				card.Owner = possessor.name
+			}
+		} else if c.IsMurderItem() {
+			card.Status = StatusMurder
+			cs.Solved = true
+			cs.Solution = c.name
+		} else if c.inEnvelopeRuledOut() {
+			card.Status = StatusHeld
+		}
+
+		// keep the player order of the game so the output is stable
+		for _, p := range g.players {
+			if c.isNonPossessor(p) {
+				card.Eliminated = append(card.Eliminated, p.name)
+			}
+		}
+
+		cs.Cards = append(cs.Cards, card)
+	}
+
+	return cs
+}
+
+func (c ConstraintSnapshot) Equals(other ConstraintSnapshot) bool {
+	return c.Player == other.Player && slices.Equal(c.Cards, other.Cards)
+}
+
+func (s Snapshot) Card(name string) (CardSnapshot, bool) {
+	for _, category := range s.Categories {
+		for _, c := range category.Cards {
+			if c.Name == name {
+				return c, true
+			}
+		}
+	}
+	return CardSnapshot{}, false
+}
+
+// JSON gives an indented encoding of the snapshot. Everything is stored in
+// slices in game order so two snapshots of the same state encode identically
+// and can be diffed line by line.
+func (s Snapshot) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
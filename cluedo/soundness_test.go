@@ -0,0 +1,187 @@
+package cluedo
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// hiddenDeal is the real state of a game that the assistant can't see. It's
+// used to check that nothing the game deduces is ever false.
+type hiddenDeal struct {
+	game     *Game
+	owner    map[*Card]*Player
+	envelope []*Card
+	// cards each player has really been shown
+	seen map[*Player][]*Card
+}
+
+// dealRandomGame deals every card of a default game out to otherOpponents+1
+// players (including us) and fills in our starting hand.
+func dealRandomGame(rng *rand.Rand, opponents int) hiddenDeal {
+	players := []*Player{}
+	for i := range opponents {
+		players = append(players, NewPlayer(fmt.Sprintf("p%d", i+1), 0))
+	}
+	game := NewDefaultGame(players...)
+
+	deal := hiddenDeal{
+		game:  &game,
+		owner: map[*Card]*Player{},
+		seen:  map[*Player][]*Card{},
+	}
+
+	rest := []*Card{}
+	for _, category := range []CardCategory{game.whoCategory, game.whatCategory, game.whereCategory} {
+		murder := rng.IntN(len(category.Cards))
+		for i, c := range category.Cards {
+			if i == murder {
+				deal.envelope = append(deal.envelope, c)
+			} else {
+				rest = append(rest, c)
+			}
+		}
+	}
+	rng.Shuffle(len(rest), func(i, j int) { rest[i], rest[j] = rest[j], rest[i] })
+
+	hand := []*Card{}
+	for i, c := range rest {
+		p := game.players[i%len(game.players)]
+		deal.owner[c] = p
+		p.cardCount++
+		if p == game.Me {
+			hand = append(hand, c)
+		}
+	}
+	game.AddStartingHand(hand)
+
+	return deal
+}
+
+// randomTurn makes a random suggestion and asks each player after the asker
+// in turn, answering truthfully until someone can show a card.
+func (d hiddenDeal) randomTurn(rng *rand.Rand) []Question {
+	g := d.game
+	askerIndex := rng.IntN(len(g.players))
+	asker := g.players[askerIndex]
+
+	who := g.whoCategory.Cards[rng.IntN(len(g.whoCategory.Cards))]
+	what := g.whatCategory.Cards[rng.IntN(len(g.whatCategory.Cards))]
+	where := g.whereCategory.Cards[rng.IntN(len(g.whereCategory.Cards))]
+
+	questions := []Question{}
+	for i := 1; i < len(g.players); i++ {
+		answerer := g.players[(askerIndex+i)%len(g.players)]
+		q := g.NewQuestion(who.ID(), what.ID(), where.ID(), PlayerID(askerIndex), PlayerID((askerIndex+i)%len(g.players)))
+
+		held := []Answer{}
+		if d.owner[who] == answerer {
+			held = append(held, WhoAnswer)
+		}
+		if d.owner[what] == answerer {
+			held = append(held, WhatAnswer)
+		}
+		if d.owner[where] == answerer {
+			held = append(held, WhereAnswer)
+		}
+
+		if len(held) == 0 {
+			q.SetAnswer(NoAnswer)
+			questions = append(questions, q)
+			continue
+		}
+
+		// the asker always sees the card even when we don't
+		q.SetAnswer(held[rng.IntN(len(held))])
+		shown, _ := q.shown()
+		d.seen[asker] = append(d.seen[asker], g.CardByID(shown))
+		if asker != g.Me {
+			q.SetAnswer(UnknownAnswer)
+		}
+		questions = append(questions, q)
+		break
+	}
+	return questions
+}
+
+// check returns a description of the first deduction that contradicts the
+// hidden deal.
+func (d hiddenDeal) check() error {
+	for _, c := range d.game.GetAllCards() {
+		owner := d.owner[c]
+		inEnvelope := slices.Contains(d.envelope, c)
+
+		if c.IsFound() && c.Possessor() != owner {
+			return fmt.Errorf("%s was marked as %s's but it's %s", c.name, playerName(c.Possessor()), playerName(owner))
+		}
+		if c.IsMurderItem() && !inEnvelope {
+			return fmt.Errorf("%s was marked as a murder element but it's %s's", c.name, playerName(owner))
+		}
+		if inEnvelope && c.inEnvelopeRuledOut() {
+			return fmt.Errorf("%s was ruled out of the envelope but it's in it", c.name)
+		}
+		if c.isNonPossessor(owner) && owner != nil {
+			return fmt.Errorf("%s was marked as not %s's but it is", c.name, owner.name)
+		}
+	}
+	for _, p := range d.game.players {
+		for _, c := range d.game.SeenBy(p) {
+			if d.owner[c] != p && !slices.Contains(d.seen[p], c) {
+				return fmt.Errorf("%s was marked as seen by %s but they've never seen it", c.name, p.name)
+			}
+		}
+	}
+	for _, clause := range d.game.Clauses() {
+		if !slices.ContainsFunc(clause.cards, func(c *Card) bool { return d.owner[c] == clause.player }) {
+			return fmt.Errorf("%s has a clause over %d cards but has none of them", clause.player.name, len(clause.cards))
+		}
+	}
+	return nil
+}
+
+func playerName(p *Player) string {
+	if p == nil {
+		return "no one"
+	}
+	return p.name
+}
+
+// playRandomGame plays turns random turns and fails at the first unsound
+// deduction.
+func playRandomGame(t *testing.T, seed uint64, opponents int, turns int) {
+	rng := rand.New(rand.NewPCG(seed, uint64(opponents)))
+	deal := dealRandomGame(rng, opponents)
+	if err := deal.check(); err != nil {
+		t.Fatalf("seed %d with %d opponents: after the starting hand: %v", seed, opponents, err)
+	}
+
+	for turn := range turns {
+		for _, q := range deal.randomTurn(rng) {
+			if _, err := deal.game.DoTurn(q); err != nil {
+				t.Fatal(err)
+			}
+			if err := deal.check(); err != nil {
+				t.Fatalf("seed %d with %d opponents: turn %d asking %s: %v", seed, opponents, turn+1, playerName(deal.game.PlayerByID(q.answerer)), err)
+			}
+		}
+	}
+}
+
+func TestDeductionSoundness(t *testing.T) {
+	for opponents := 2; opponents <= 5; opponents++ {
+		for seed := range uint64(200) {
+			playRandomGame(t, seed, opponents, 40)
+		}
+	}
+}
+
+func FuzzDeductionSoundness(f *testing.F) {
+	f.Add(uint64(0), uint8(2), uint8(20))
+	f.Add(uint64(1), uint8(3), uint8(40))
+	f.Add(uint64(42), uint8(5), uint8(60))
+
+	f.Fuzz(func(t *testing.T, seed uint64, opponents uint8, turns uint8) {
+		playRandomGame(t, seed, int(opponents%5)+1, int(turns))
+	})
+}
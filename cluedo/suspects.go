@@ -0,0 +1,82 @@
+package cluedo
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SetSuspect records which suspect's token player is playing as. Nobody has
+// to have one but no two players can play the same suspect.
+func (g *Game) SetSuspect(player *Player, suspect string) error {
+	p := g.k.playerIndex(player)
+	if p < 0 {
+		return fmt.Errorf("`%s` isn't playing", player.name)
+	}
+	id, err := g.LookupCard(suspect)
+	if err != nil || !g.whoCategory.set().has(int(id)) {
+		return fmt.Errorf("`%s` isn't a who card", suspect)
+	}
+	for other, s := range g.suspects {
+		if s == id && other != PlayerID(p) {
+			return fmt.Errorf("`%s` is already playing as %s", g.PlayerByID(other).name, suspect)
+		}
+	}
+
+	g.suspects[PlayerID(p)] = id
+	return nil
+}
+
+// Suspect is the suspect player is playing as, if it's been set.
+func (g *Game) Suspect(player *Player) (string, bool) {
+	id, ok := g.suspects[PlayerID(g.k.playerIndex(player))]
+	if !ok {
+		return "", false
+	}
+	return g.CardByID(id).name, true
+}
+
+// TurnOrder is the order players take their turns in. When everyone's
+// suspect is known it follows the board's edition, where Scarlet goes first,
+// otherwise it's the order the players were added to the game. Players whose
+// suspect isn't in the edition's turn order go last.
+func (g *Game) TurnOrder() []*Player {
+	order := slices.Clone(g.players)
+	if len(g.suspects) != len(g.players) {
+		return order
+	}
+
+	// suspects the board doesn't give a turn to go after everyone else
+	rank := func(p *Player) int {
+		suspect, _ := g.Suspect(p)
+		if i := slices.Index(g.board.turnOrder, suspect); i >= 0 {
+			return i
+		}
+		return len(g.board.turnOrder)
+	}
+	slices.SortStableFunc(order, func(a, b *Player) int {
+		return rank(a) - rank(b)
+	})
+	return order
+}
+
+// playing is the player playing as suspect, if any.
+func (g *Game) playing(suspect CardID) (PlayerID, bool) {
+	for p, s := range g.suspects {
+		if s == suspect {
+			return p, true
+		}
+	}
+	return 0, false
+}
+
+// playerLabel is how a player is shown in the grid header.
+func (g *Game) playerLabel(player *Player) string {
+	label := player.name
+	if player == g.Me {
+		label = "you"
+	}
+	if suspect, ok := g.Suspect(player); ok {
+		label += " (" + suspect + ")"
+	}
+	return label
+}
@@ -0,0 +1,438 @@
+package cluedo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// A transcript is a plain text record of a game. Blank lines and lines
+// starting with # are ignored. The first line lists the players and how many
+// cards they hold, an optional hand line lists our own cards and every other
+// line is a suggestion or an accusation:
+//
+//	players alice=5 bob=5 charlie=4
+//	hand peacock, white, rope, bathroom
+//	suggest ME: white, dagger, study | pass alice bob | show charlie dagger
+//	suggest alice: peacock, lead pipe, garage | show bob
+//	suggest bob: mustard, lead pipe, kitchen | pass charlie ME alice
+//	accuse alice: plum, rope, kitchen
+//
+// Passes are listed in the order they happened. The card after the shower is
+// only known when it was shown to us and can be left off otherwise.
+// Accusations are kept for the record but don't change what's known.
+type Transcript struct {
+	Lines []TranscriptLine
+}
+
+type TranscriptKind int
+
+const (
+	PlayersLine TranscriptKind = iota
+	HandLine
+	SuggestLine
+	AccuseLine
+)
+
+type TranscriptLine struct {
+	Number int
+	Text   string
+	Kind   TranscriptKind
+
+	// PlayersLine
+	Players []TranscriptPlayer
+
+	// HandLine, SuggestLine and AccuseLine
+	Cards []string
+
+	// SuggestLine and AccuseLine
+	Asker string
+
+	// SuggestLine
+	Passes []string
+	Shower string
+	Shown  string
+}
+
+type TranscriptPlayer struct {
+	Name      string
+	CardCount int
+}
+
+type TranscriptError struct {
+	Line int
+	Err  error
+}
+
+func (e TranscriptError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e TranscriptError) Unwrap() error {
+	return e.Err
+}
+
+func ParseTranscript(r io.Reader) (Transcript, error) {
+	t := Transcript{}
+
+	scanner := bufio.NewScanner(r)
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		line, err := ParseTranscriptLine(text)
+		if err != nil {
+			return t, TranscriptError{Line: number, Err: err}
+		}
+		line.Number = number
+
+		if err := t.checkOrder(line); err != nil {
+			return t, TranscriptError{Line: number, Err: err}
+		}
+		t.Lines = append(t.Lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return t, err
+	}
+	if len(t.Lines) == 0 {
+		return t, errors.New("transcript is empty")
+	}
+
+	return t, nil
+}
+
+func (t Transcript) checkOrder(line TranscriptLine) error {
+	if len(t.Lines) == 0 {
+		if line.Kind != PlayersLine {
+			return errors.New("transcript must start with a players line")
+		}
+		return nil
+	}
+
+	switch line.Kind {
+	case PlayersLine:
+		return errors.New("players can only be given once")
+	case HandLine:
+		if t.Lines[len(t.Lines)-1].Kind != PlayersLine {
+			return errors.New("hand must come straight after the players")
+		}
+	}
+	return nil
+}
+
+func ParseTranscriptLine(text string) (TranscriptLine, error) {
+	line := TranscriptLine{
+		Text: text,
+	}
+
+	keyword, rest, _ := strings.Cut(text, " ")
+	rest = strings.TrimSpace(rest)
+
+	switch keyword {
+	case "players":
+		line.Kind = PlayersLine
+		for _, field := range strings.Fields(rest) {
+			name, count, ok := strings.Cut(field, "=")
+			if !ok {
+				return line, fmt.Errorf("player `%s` should be written as name=cards", field)
+			}
+			n, err := strconv.Atoi(count)
+			if err != nil || n < 0 {
+				return line, fmt.Errorf("`%s` isn't a valid card count for %s", count, name)
+			}
+			line.Players = append(line.Players, TranscriptPlayer{
+				Name:      name,
+				CardCount: n,
+			})
+		}
+		if len(line.Players) == 0 {
+			return line, errors.New("no players given")
+		}
+	case "hand":
+		line.Kind = HandLine
+		line.Cards = splitCards(rest)
+	case "suggest":
+		line.Kind = SuggestLine
+		if err := parseSuggestion(&line, rest); err != nil {
+			return line, err
+		}
+	case "accuse":
+		line.Kind = AccuseLine
+		if err := parseAccusation(&line, rest); err != nil {
+			return line, err
+		}
+	default:
+		return line, fmt.Errorf("unknown line type `%s`", keyword)
+	}
+
+	return line, nil
+}
+
+func parseSuggestion(line *TranscriptLine, text string) error {
+	parts := strings.Split(text, "|")
+
+	asker, cards, ok := strings.Cut(parts[0], ":")
+	if !ok {
+		return errors.New("suggestion should be written as asker: who, what, where")
+	}
+	line.Asker = normalisePlayer(asker)
+	line.Cards = splitCards(cards)
+	if len(line.Cards) != 3 {
+		return fmt.Errorf("suggestion needs 3 cards but has %d", len(line.Cards))
+	}
+
+	for _, part := range parts[1:] {
+		keyword, rest, _ := strings.Cut(strings.TrimSpace(part), " ")
+		switch keyword {
+		case "pass":
+			if line.Shower != "" {
+				return errors.New("no one can pass after a card has been shown")
+			}
+			for _, p := range strings.Fields(rest) {
+				line.Passes = append(line.Passes, normalisePlayer(p))
+			}
+		case "show":
+			if line.Shower != "" {
+				return errors.New("only one player can show a card")
+			}
+			shower, card, _ := strings.Cut(strings.TrimSpace(rest), " ")
+			if shower == "" {
+				return errors.New("show needs a player")
+			}
+			line.Shower = normalisePlayer(shower)
+			line.Shown = strings.TrimSpace(card)
+		default:
+			return fmt.Errorf("unknown suggestion part `%s`", keyword)
+		}
+	}
+
+	return nil
+}
+
+func parseAccusation(line *TranscriptLine, text string) error {
+	accuser, cards, ok := strings.Cut(text, ":")
+	if !ok {
+		return errors.New("accusation should be written as accuser: who, what, where")
+	}
+	line.Asker = normalisePlayer(accuser)
+	line.Cards = splitCards(cards)
+	if len(line.Cards) != 3 {
+		return fmt.Errorf("accusation needs 3 cards but has %d", len(line.Cards))
+	}
+	return nil
+}
+
+func splitCards(text string) []string {
+	cards := []string{}
+	for _, c := range strings.Split(text, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}
+
+func normalisePlayer(name string) string {
+	name = strings.TrimSpace(name)
+	if strings.EqualFold(name, MeIdent) {
+		return MeIdent
+	}
+	return name
+}
+
+// Replay plays every line of the transcript into a new default game. each is
+// called after every line with the results of all the turns it took.
+func (t Transcript) Replay(each func(line TranscriptLine, game *Game, result TurnResult)) (*Game, error) {
+	if len(t.Lines) == 0 || t.Lines[0].Kind != PlayersLine {
+		return nil, errors.New("transcript must start with a players line")
+	}
+
+	players := []*Player{}
+	for _, p := range t.Lines[0].Players {
+		if p.Name == MeIdent {
+			return nil, TranscriptError{Line: t.Lines[0].Number, Err: fmt.Errorf("can't have a player called `%s`", MeIdent)}
+		}
+		for _, other := range players {
+			if other.name == p.Name {
+				return nil, TranscriptError{Line: t.Lines[0].Number, Err: fmt.Errorf("player `%s` is listed twice", p.Name)}
+			}
+		}
+		players = append(players, NewPlayer(p.Name, p.CardCount))
+	}
+	game := NewDefaultGame(players...)
+	if each != nil {
+		each(t.Lines[0], &game, TurnResult{})
+	}
+
+	for _, line := range t.Lines[1:] {
+		result, err := game.PlayLine(line)
+		if err != nil {
+			return &game, TranscriptError{Line: line.Number, Err: err}
+		}
+		if each != nil {
+			each(line, &game, result)
+		}
+	}
+
+	return &game, nil
+}
+
+// PlayLine plays a hand, suggestion or accusation line into the game. The
+// players line can only be used to start a game with Replay.
+func (g *Game) PlayLine(line TranscriptLine) (TurnResult, error) {
+	switch line.Kind {
+	case HandLine:
+		hand := []*Card{}
+		for _, name := range line.Cards {
+			id, err := g.LookupCard(name)
+			if err != nil {
+				return TurnResult{}, err
+			}
+			hand = append(hand, g.CardByID(id))
+		}
+		if err := g.checkDeal(len(hand)); err != nil {
+			return TurnResult{}, err
+		}
+		before := g.Snapshot()
+		g.AddStartingHand(hand)
+		return diffSnapshots(before, g.Snapshot(), g.turn, g.constraintTurns, ""), nil
+	case SuggestLine:
+		if err := g.checkDeal(g.Me.cardCount); err != nil {
+			return TurnResult{}, err
+		}
+		return g.playSuggestion(line)
+	case AccuseLine:
+		if err := g.checkDeal(g.Me.cardCount); err != nil {
+			return TurnResult{}, err
+		}
+		return g.playAccusation(line)
+	}
+	return TurnResult{}, errors.New("players can only be given once")
+}
+
+// checkDeal makes sure the hands, with ours holding mine cards, add up to
+// every card that isn't in the envelope.
+func (g *Game) checkDeal(mine int) error {
+	held := mine
+	for _, p := range g.players {
+		if p != g.Me {
+			held += p.cardCount
+		}
+	}
+	if dealt := len(g.cards) - len(g.categories()); held != dealt {
+		return fmt.Errorf("the hands add up to %d cards but %d are dealt", held, dealt)
+	}
+	return nil
+}
+
+// playAccusation only checks the accusation makes sense. A wrong accusation
+// rules out one combination of envelope cards, which is too little to be
+// worth tracking.
+func (g *Game) playAccusation(line TranscriptLine) (TurnResult, error) {
+	if _, err := g.LookupPlayer(line.Asker); err != nil {
+		return TurnResult{}, err
+	}
+	categories := g.categories()
+	for i, name := range line.Cards {
+		id, err := g.LookupCard(name)
+		if err != nil {
+			return TurnResult{}, err
+		}
+		if !categories[i].set().has(int(id)) {
+			return TurnResult{}, fmt.Errorf("`%s` isn't a %s card", name, categoryNames[i])
+		}
+	}
+	return TurnResult{Turn: g.turn, Facts: []Fact{}}, nil
+}
+
+func (g *Game) playSuggestion(line TranscriptLine) (TurnResult, error) {
+	cards := [3]CardID{}
+	for i, name := range line.Cards {
+		id, err := g.LookupCard(name)
+		if err != nil {
+			return TurnResult{}, err
+		}
+		cards[i] = id
+	}
+
+	asker, err := g.LookupPlayer(line.Asker)
+	if err != nil {
+		return TurnResult{}, err
+	}
+	// the suggestion has to make sense before anyone's answers to it do
+	if err := g.validateCards(cards); err != nil {
+		return TurnResult{}, err
+	}
+	if len(line.Passes) == 0 && line.Shower == "" {
+		return TurnResult{}, errors.New("suggestion needs someone to pass or show")
+	}
+
+	questions := []Question{}
+	for _, name := range line.Passes {
+		passer, err := g.LookupPlayer(name)
+		if err != nil {
+			return TurnResult{}, err
+		}
+		for _, c := range cards {
+			if g.CardByID(c).Possessor() == g.PlayerByID(passer) {
+				return TurnResult{}, fmt.Errorf("`%s` can't pass because they have %s", name, g.CardByID(c).name)
+			}
+		}
+		q := g.NewQuestion(cards[0], cards[1], cards[2], asker, passer)
+		q.SetAnswer(NoAnswer)
+		questions = append(questions, q)
+	}
+
+	if line.Shower != "" {
+		shower, err := g.LookupPlayer(line.Shower)
+		if err != nil {
+			return TurnResult{}, err
+		}
+		if slices.Contains(line.Passes, line.Shower) {
+			return TurnResult{}, fmt.Errorf("`%s` can't pass and show", line.Shower)
+		}
+
+		q := g.NewQuestion(cards[0], cards[1], cards[2], asker, shower)
+		switch line.Shown {
+		case "":
+			q.SetAnswer(UnknownAnswer)
+		case line.Cards[0]:
+			q.SetAnswer(WhoAnswer)
+		case line.Cards[1]:
+			q.SetAnswer(WhatAnswer)
+		case line.Cards[2]:
+			q.SetAnswer(WhereAnswer)
+		default:
+			return TurnResult{}, fmt.Errorf("shown card `%s` wasn't part of the suggestion", line.Shown)
+		}
+		questions = append(questions, q)
+	}
+
+	for _, q := range questions {
+		if err := g.ValidateQuestion(q); err != nil {
+			return TurnResult{}, err
+		}
+	}
+
+	result := TurnResult{
+		Facts: []Fact{},
+	}
+	for _, q := range questions {
+		// everything was checked above so this can't fail part way
+		r, err := g.DoTurn(q)
+		if err != nil {
+			return result, err
+		}
+		result.Turn = r.Turn
+		result.Facts = append(result.Facts, r.Facts...)
+	}
+	return result, nil
+}
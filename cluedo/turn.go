@@ -0,0 +1,224 @@
+package cluedo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type FactKind int
+
+const (
+	FactFound FactKind = iota
+	FactNonPossessor
+	FactMurder
+	FactHeld
+	FactConstraintAdded
+	FactConstraintResolved
+	FactCategorySolved
+)
+
+type Fact struct {
+	Kind   FactKind
+	Player string
+	Cards  []string
+
+	// only set for FactCategorySolved
+	Category string
+
+	// set when a found card came from resolving a constraint
+	Via     *ConstraintSnapshot
+	ViaTurn int
+}
+
+type TurnResult struct {
+	Turn  int
+	Facts []Fact
+}
+
+func (f Fact) String() string {
+	player := f.Player
+	possessive := player + "'s"
+	has := "has"
+	doesnt := "doesn't"
+	if player == MeIdent {
+		player = "you"
+		possessive = "your"
+		has = "have"
+		doesnt = "don't"
+	}
+
+	switch f.Kind {
+	case FactFound:
+		str := fmt.Sprintf("%s %s %s", player, has, f.Cards[0])
+		if f.Via != nil {
+			str += fmt.Sprintf(" (via %s from turn %d)", linkName(*f.Via), f.ViaTurn)
+		}
+		return str
+	case FactNonPossessor:
+		return fmt.Sprintf("%s %s have %s", player, doesnt, f.Cards[0])
+	case FactMurder:
+		return fmt.Sprintf("%s is a murder element", f.Cards[0])
+	case FactHeld:
+		return fmt.Sprintf("%s isn't in the envelope", f.Cards[0])
+	case FactConstraintAdded:
+		return fmt.Sprintf("%s %s at least one of %s", player, has, strings.Join(f.Cards, ", "))
+	case FactConstraintResolved:
+		return fmt.Sprintf("%s %s on %s is resolved", possessive, linkName(ConstraintSnapshot{Cards: f.Cards}), strings.Join(f.Cards, ", "))
+	case FactCategorySolved:
+		return fmt.Sprintf("%s is solved: %s", f.Category, f.Cards[0])
+	}
+	return "unknown fact"
+}
+
+func linkName(c ConstraintSnapshot) string {
+	switch len(c.Cards) {
+	case 2:
+		return "link"
+	case 3:
+		return "trilink"
+	}
+	return "clause"
+}
+
+func (r TurnResult) String() string {
+	str := strings.Builder{}
+	for _, f := range r.Facts {
+		str.WriteString("New: " + f.String() + "\n")
+	}
+	return str.String()
+}
+
+func (r TurnResult) Found() []Fact {
+	found := []Fact{}
+	for _, f := range r.Facts {
+		if f.Kind == FactFound {
+			found = append(found, f)
+		}
+	}
+	return found
+}
+
+// diffSnapshots lists everything that is known in after but wasn't in before.
+// constraintTurns is used to work out which turn a resolved constraint came
+// from and is updated with any constraints created this turn. shown is the
+// card that was directly seen this turn, if any, so it isn't credited to a
+// constraint.
+func diffSnapshots(before, after Snapshot, turn int, constraintTurns map[string]int, shown string) TurnResult {
+	result := TurnResult{
+		Turn:  turn,
+		Facts: []Fact{},
+	}
+
+	resolved := []ConstraintSnapshot{}
+	for _, c := range before.Constraints {
+		if !slices.ContainsFunc(after.Constraints, c.Equals) {
+			resolved = append(resolved, c)
+		}
+	}
+	added := []ConstraintSnapshot{}
+	for _, c := range after.Constraints {
+		if !slices.ContainsFunc(before.Constraints, c.Equals) {
+			added = append(added, c)
+		}
+	}
+
+	for i, category := range after.Categories {
+		for j, card := range category.Cards {
+			old := before.Categories[i].Cards[j]
+
+			if card.Status == StatusFound && old.Status != StatusFound {
+				fact := Fact{
+					Kind:   FactFound,
+					Player: card.Owner,
+					Cards:  []string{card.Name},
+				}
+				for _, c := range resolved {
+					if card.Name == shown {
+						break
+					}
+					if c.Player != card.Owner || !slices.Contains(c.Cards, card.Name) {
+						continue
+					}
+					via := c
+					fact.Via = &via
+					fact.ViaTurn = constraintTurns[c.key()]
+					break
+				}
+				result.Facts = append(result.Facts, fact)
+			}
+			if card.Status == StatusHeld && old.Status == StatusUnknown {
+				result.Facts = append(result.Facts, Fact{
+					Kind:  FactHeld,
+					Cards: []string{card.Name},
+				})
+			}
+			if card.Status == StatusMurder && old.Status != StatusMurder {
+				result.Facts = append(result.Facts, Fact{
+					Kind:  FactMurder,
+					Cards: []string{card.Name},
+				})
+			}
+			for _, p := range card.Eliminated {
+				if p == card.Owner || slices.Contains(old.Eliminated, p) {
+					continue
+				}
+				result.Facts = append(result.Facts, Fact{
+					Kind:   FactNonPossessor,
+					Player: p,
+					Cards:  []string{card.Name},
+				})
+			}
+		}
+	}
+
+	for _, c := range added {
+		// a link shrunk from a trilink keeps the turn the trilink was made on
+		origin := turn
+		for _, r := range resolved {
+			if r.Player == c.Player && isSubset(c.Cards, r.Cards) {
+				origin = constraintTurns[r.key()]
+				break
+			}
+		}
+		constraintTurns[c.key()] = origin
+
+		result.Facts = append(result.Facts, Fact{
+			Kind:   FactConstraintAdded,
+			Player: c.Player,
+			Cards:  c.Cards,
+		})
+	}
+	for _, c := range resolved {
+		result.Facts = append(result.Facts, Fact{
+			Kind:   FactConstraintResolved,
+			Player: c.Player,
+			Cards:  c.Cards,
+		})
+	}
+
+	for i, category := range after.Categories {
+		if category.Solved && !before.Categories[i].Solved {
+			result.Facts = append(result.Facts, Fact{
+				Kind:     FactCategorySolved,
+				Cards:    []string{category.Solution},
+				Category: category.Name,
+			})
+		}
+	}
+
+	return result
+}
+
+func (c ConstraintSnapshot) key() string {
+	return c.Player + "\x00" + strings.Join(c.Cards, "\x00")
+}
+
+func isSubset[T comparable](sub, set []T) bool {
+	for _, s := range sub {
+		if !slices.Contains(set, s) {
+			return false
+		}
+	}
+	return true
+}
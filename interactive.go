@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
+	"github.com/moltenwolfcub/cluedoAssistant/stats"
+	"github.com/moltenwolfcub/cluedoAssistant/tui"
+)
+
+func interactive(args []string) int {
+	flags := flag.NewFlagSet("tui", flag.ContinueOnError)
+	bluff := flags.Float64("bluff", 0, "share of their suggested cards opponents are assumed to hold themselves, 0 for none")
+	statsDir := flags.String("stats", "", "directory of saved games to learn opponents' habits from")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: cluedoAssistant tui [-bluff rate] [-stats dir] [transcript]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 1 || *bluff < 0 || *bluff >= 1 {
+		flags.Usage()
+		return 2
+	}
+
+	app := tui.New(tui.StdTerminal{}, os.Stdin, os.Stdout)
+	app.BluffRate = *bluff
+	if *statsDir != "" {
+		s, err := stats.Load(*statsDir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		app.Priors = s.Priors()
+	}
+	if flags.NArg() == 1 {
+		file, err := os.Open(flags.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer file.Close()
+
+		transcript, err := cluedo.ParseTranscript(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", flags.Arg(0), err)
+			return 1
+		}
+		lines := []string{}
+		for _, line := range transcript.Lines {
+			lines = append(lines, line.Text)
+		}
+		if err := app.Play(lines...); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", flags.Arg(0), err)
+			return 1
+		}
+	}
+
+	if err := app.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
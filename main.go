@@ -2,11 +2,25 @@ package main
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "replay":
+			os.Exit(replay(os.Args[2:]))
+		case "tui":
+			os.Exit(interactive(os.Args[2:]))
+		case "session":
+			os.Exit(sessions(os.Args[2:]))
+		case "stats":
+			os.Exit(statistics(os.Args[2:]))
+		}
+	}
+
 	alice := cluedo.NewPlayer("alice", 5)
 	bob := cluedo.NewPlayer("bob", 5)
 	charlie := cluedo.NewPlayer("charlie", 4)
@@ -26,170 +40,153 @@ func main() {
 	)
 
 	AskQuestion(
-		game,
-		cluedo.NewQuestion(
-			cluedo.NewCard("white"),
-			cluedo.NewCard("dagger"),
-			cluedo.NewCard("study"),
-			game.Me,
-			alice,
-		),
+		&game,
+		cluedo.NewCard("white"),
+		cluedo.NewCard("dagger"),
+		cluedo.NewCard("study"),
+		game.Me,
+		alice,
 		cluedo.NoAnswer,
 	)
 	AskQuestion(
-		game,
-		cluedo.NewQuestion(
-			cluedo.NewCard("white"),
-			cluedo.NewCard("dagger"),
-			cluedo.NewCard("study"),
-			game.Me,
-			bob,
-		),
+		&game,
+		cluedo.NewCard("white"),
+		cluedo.NewCard("dagger"),
+		cluedo.NewCard("study"),
+		game.Me,
+		bob,
 		cluedo.NoAnswer,
 	)
 	AskQuestion(
-		game,
-		cluedo.NewQuestion(
-			cluedo.NewCard("white"),
-			cluedo.NewCard("dagger"),
-			cluedo.NewCard("study"),
-			game.Me,
-			charlie,
-		),
+		&game,
+		cluedo.NewCard("white"),
+		cluedo.NewCard("dagger"),
+		cluedo.NewCard("study"),
+		game.Me,
+		charlie,
 		cluedo.WhatAnswer,
 	)
 
 	AskQuestion(
-		game,
-		cluedo.NewQuestion(
-			cluedo.NewCard("peacock"),
-			cluedo.NewCard("lead pipe"),
-			cluedo.NewCard("garage"),
-			alice,
-			bob,
-		),
+		&game,
+		cluedo.NewCard("peacock"),
+		cluedo.NewCard("lead pipe"),
+		cluedo.NewCard("garage"),
+		alice,
+		bob,
 		cluedo.UnknownAnswer,
 	)
 
 	AskQuestion(
-		game,
-		cluedo.NewQuestion(
-			cluedo.NewCard("mustard"),
-			cluedo.NewCard("lead pipe"),
-			cluedo.NewCard("kitchen"),
-			bob,
-			charlie,
-		),
+		&game,
+		cluedo.NewCard("mustard"),
+		cluedo.NewCard("lead pipe"),
+		cluedo.NewCard("kitchen"),
+		bob,
+		charlie,
 		cluedo.NoAnswer,
 	)
 	AskQuestion(
-		game,
-		cluedo.NewQuestion(
-			cluedo.NewCard("mustard"),
-			cluedo.NewCard("lead pipe"),
-			cluedo.NewCard("kitchen"),
-			bob,
-			game.Me,
-		),
+		&game,
+		cluedo.NewCard("mustard"),
+		cluedo.NewCard("lead pipe"),
+		cluedo.NewCard("kitchen"),
+		bob,
+		game.Me,
 		cluedo.NoAnswer,
 	)
 	AskQuestion(
-		game,
-		cluedo.NewQuestion(
-			cluedo.NewCard("mustard"),
-			cluedo.NewCard("lead pipe"),
-			cluedo.NewCard("kitchen"),
-			bob,
-			alice,
-		),
+		&game,
+		cluedo.NewCard("mustard"),
+		cluedo.NewCard("lead pipe"),
+		cluedo.NewCard("kitchen"),
+		bob,
+		alice,
 		cluedo.UnknownAnswer,
 	)
 
 	AskQuestion(
-		game,
-		cluedo.NewQuestion(
-			cluedo.NewCard("peacock"),
-			cluedo.NewCard("rope"),
-			cluedo.NewCard("bathroom"),
-			charlie,
-			game.Me,
-		),
+		&game,
+		cluedo.NewCard("peacock"),
+		cluedo.NewCard("rope"),
+		cluedo.NewCard("bathroom"),
+		charlie,
+		game.Me,
 		cluedo.WhereAnswer,
 	)
 
 	AskQuestion(
-		game,
-		cluedo.NewQuestion(
-			cluedo.NewCard("mustard"),
-			cluedo.NewCard("lead pipe"),
-			cluedo.NewCard("kitchen"),
-			game.Me,
-			alice,
-		),
+		&game,
+		cluedo.NewCard("mustard"),
+		cluedo.NewCard("lead pipe"),
+		cluedo.NewCard("kitchen"),
+		game.Me,
+		alice,
 		cluedo.WhatAnswer,
 	)
 
 	AskQuestion(
-		game,
-		cluedo.NewQuestion(
-			cluedo.NewCard("white"),
-			cluedo.NewCard("wrench"),
-			cluedo.NewCard("courtyard"),
-			game.Me,
-			alice,
-		),
+		&game,
+		cluedo.NewCard("white"),
+		cluedo.NewCard("wrench"),
+		cluedo.NewCard("courtyard"),
+		game.Me,
+		alice,
 		cluedo.WhereAnswer,
 	)
 	AskQuestion(
-		game,
-		cluedo.NewQuestion(
-			cluedo.NewCard("white"),
-			cluedo.NewCard("wrench"),
-			cluedo.NewCard("dining room"),
-			game.Me,
-			alice,
-		),
+		&game,
+		cluedo.NewCard("white"),
+		cluedo.NewCard("wrench"),
+		cluedo.NewCard("dining room"),
+		game.Me,
+		alice,
 		cluedo.WhereAnswer,
 	)
 	AskQuestion(
-		game,
-		cluedo.NewQuestion(
-			cluedo.NewCard("green"),
-			cluedo.NewCard("wrench"),
-			cluedo.NewCard("dining room"),
-			game.Me,
-			alice,
-		),
+		&game,
+		cluedo.NewCard("green"),
+		cluedo.NewCard("wrench"),
+		cluedo.NewCard("dining room"),
+		game.Me,
+		alice,
 		cluedo.WhoAnswer,
 	)
 	AskQuestion(
-		game,
-		cluedo.NewQuestion(
-			cluedo.NewCard("green"),
-			cluedo.NewCard("pistol"),
-			cluedo.NewCard("dining room"),
-			game.Me,
-			alice,
-		),
+		&game,
+		cluedo.NewCard("green"),
+		cluedo.NewCard("pistol"),
+		cluedo.NewCard("dining room"),
+		game.Me,
+		alice,
 		cluedo.WhatAnswer,
 	)
 
 	AskQuestion(
-		game,
-		cluedo.NewQuestion(
-			cluedo.NewCard("plum"),
-			cluedo.NewCard("wrench"),
-			cluedo.NewCard("dining room"),
-			game.Me,
-			bob,
-		),
+		&game,
+		cluedo.NewCard("plum"),
+		cluedo.NewCard("wrench"),
+		cluedo.NewCard("dining room"),
+		game.Me,
+		bob,
 		cluedo.WhatAnswer,
 	)
 
-	fmt.Println(game)
+	fmt.Println(&game)
 }
 
-func AskQuestion(g cluedo.Game, q cluedo.Question, a cluedo.Answer) {
+func AskQuestion(g *cluedo.Game, who, what, where *cluedo.Card, asker, answerer *cluedo.Player, a cluedo.Answer) {
+	q, err := g.QuestionFor(who, what, where, asker, answerer)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
 	q.SetAnswer(a)
-	g.DoTurn(q)
+
+	result, err := g.DoTurn(q)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Print(result)
 }
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
+)
+
+func replay(args []string) int {
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	everyLine := flags.Bool("each", false, "print the grid after every line instead of only at the end")
+	quiet := flags.Bool("quiet", false, "don't print the new facts from each line")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: cluedoAssistant replay [-each] [-quiet] <transcript>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer file.Close()
+
+	transcript, err := cluedo.ParseTranscript(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", flags.Arg(0), err)
+		return 1
+	}
+
+	game, err := transcript.Replay(func(line cluedo.TranscriptLine, game *cluedo.Game, result cluedo.TurnResult) {
+		if *everyLine || !*quiet {
+			fmt.Printf("%d: %s\n", line.Number, line.Text)
+		}
+		if !*quiet {
+			fmt.Print(result)
+		}
+		if *everyLine {
+			fmt.Println(game)
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", flags.Arg(0), err)
+		return 1
+	}
+
+	if !*everyLine {
+		fmt.Println(game)
+	}
+	return 0
+}
@@ -0,0 +1,270 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	transcriptExt = ".txt"
+	redoExt       = ".redo"
+	archiveDir    = "archive"
+	currentFile   = "current"
+)
+
+var ErrNoCurrent = errors.New("no session has been chosen")
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// Manager keeps every session in a directory, one transcript each. Finished
+// sessions can be moved into an archive directory inside it and brought back
+// later. Any number of sessions can be open at once.
+type Manager struct {
+	dir string
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// Info describes a stored session.
+type Info struct {
+	Name     string
+	Archived bool
+	Lines    int
+	Modified time.Time
+}
+
+// Open uses dir to store sessions, creating it if needed.
+func Open(dir string) (*Manager, error) {
+	if err := os.MkdirAll(filepath.Join(dir, archiveDir), 0o755); err != nil {
+		return nil, err
+	}
+	return &Manager{
+		dir:      dir,
+		sessions: map[string]*Session{},
+	}, nil
+}
+
+func checkName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("`%s` isn't a valid session name, use letters, numbers, - and _", name)
+	}
+	return nil
+}
+
+func (m *Manager) path(name string, archived bool) string {
+	if archived {
+		return filepath.Join(m.dir, archiveDir, name+transcriptExt)
+	}
+	return filepath.Join(m.dir, name+transcriptExt)
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Create starts a new empty session and makes it the current one.
+func (m *Manager) Create(name string) (*Session, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if exists(m.path(name, false)) || exists(m.path(name, true)) {
+		return nil, fmt.Errorf("session `%s` already exists", name)
+	}
+
+	s := &Session{
+		name: name,
+		path: m.path(name, false),
+	}
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	m.sessions[name] = s
+	return s, m.setCurrent(name)
+}
+
+// Get opens a session that isn't archived.
+func (m *Manager) Get(name string) (*Session, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.get(name)
+}
+
+func (m *Manager) get(name string) (*Session, error) {
+	if s, ok := m.sessions[name]; ok {
+		return s, nil
+	}
+
+	path := m.path(name, false)
+	if !exists(path) {
+		if exists(m.path(name, true)) {
+			return nil, fmt.Errorf("session `%s` is archived", name)
+		}
+		return nil, fmt.Errorf("no session called `%s`", name)
+	}
+
+	s := &Session{
+		name: name,
+		path: path,
+	}
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("session `%s`: %w", name, err)
+	}
+	m.sessions[name] = s
+	return s, nil
+}
+
+// Switch makes name the current session.
+func (m *Manager) Switch(name string) (*Session, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, err := m.get(name)
+	if err != nil {
+		return nil, err
+	}
+	return s, m.setCurrent(name)
+}
+
+// Current is the session last created or switched to. It's remembered on
+// disk so it's the same between runs.
+func (m *Manager) Current() (*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, err := os.ReadFile(filepath.Join(m.dir, currentFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoCurrent
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(string(data))
+	if name == "" {
+		return nil, ErrNoCurrent
+	}
+	return m.get(name)
+}
+
+func (m *Manager) setCurrent(name string) error {
+	return writeLines(filepath.Join(m.dir, currentFile), []string{name})
+}
+
+// List gives every stored session, archived ones last, each sorted by name.
+func (m *Manager) List() ([]Info, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	infos := []Info{}
+	for _, archived := range []bool{false, true} {
+		dir := m.dir
+		if archived {
+			dir = filepath.Join(m.dir, archiveDir)
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range entries {
+			name, ok := strings.CutSuffix(e.Name(), transcriptExt)
+			if e.IsDir() || !ok || checkName(name) != nil {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			stat, err := os.Stat(path)
+			if err != nil {
+				return nil, err
+			}
+			lines, err := readLines(path)
+			if err != nil {
+				return nil, err
+			}
+			infos = append(infos, Info{
+				Name:     name,
+				Archived: archived,
+				Lines:    len(lines),
+				Modified: stat.ModTime(),
+			})
+		}
+	}
+	return infos, nil
+}
+
+// Archive moves a finished session out of the way. It can't be played until
+// it's restored.
+func (m *Manager) Archive(name string) error {
+	return m.move(name, false)
+}
+
+// Restore brings an archived session back.
+func (m *Manager) Restore(name string) error {
+	return m.move(name, true)
+}
+
+func (m *Manager) move(name string, archived bool) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	from, to := m.path(name, archived), m.path(name, !archived)
+	if !exists(from) {
+		if archived {
+			return fmt.Errorf("no archived session called `%s`", name)
+		}
+		return fmt.Errorf("no session called `%s`", name)
+	}
+	if exists(to) {
+		return fmt.Errorf("session `%s` already exists", name)
+	}
+
+	// nothing can be saved to the old place once the files have moved. If
+	// the move fails the session is loaded again next time it's used
+	if s, ok := m.sessions[name]; ok {
+		s.close()
+		delete(m.sessions, name)
+	}
+
+	if err := os.Rename(from, to); err != nil {
+		return err
+	}
+	redoFrom := strings.TrimSuffix(from, transcriptExt) + redoExt
+	if exists(redoFrom) {
+		if err := os.Rename(redoFrom, strings.TrimSuffix(to, transcriptExt)+redoExt); err != nil {
+			return err
+		}
+	}
+
+	if archived {
+		return nil
+	}
+	// an archived session can't be current
+	data, err := os.ReadFile(filepath.Join(m.dir, currentFile))
+	if err == nil && strings.TrimSpace(string(data)) == name {
+		return os.Remove(filepath.Join(m.dir, currentFile))
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
+)
+
+var ErrNothingToUndo = errors.New("nothing to undo")
+var ErrNothingToRedo = errors.New("nothing to redo")
+
+// Session is one named game. It's stored as a transcript so undoing a line
+// replays the game without it.
+type Session struct {
+	name string
+	path string
+
+	mu   sync.Mutex
+	game *cluedo.Game
+	// the transcript lines played so far
+	lines []string
+	// lines that have been undone, most recent last
+	undone []string
+	// set once the session has been archived
+	closed bool
+}
+
+func (s *Session) Name() string {
+	return s.name
+}
+
+// Lines is the transcript of the game so far.
+func (s *Session) Lines() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.lines)
+}
+
+// Read calls f with the session's game, which is nil until the players have
+// been given. The game mustn't be kept or changed after f returns.
+func (s *Session) Read(f func(game *cluedo.Game)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(s.game)
+}
+
+// Play plays one transcript line and saves the session. Anything undone can
+// no longer be redone.
+func (s *Session) Play(text string) (cluedo.TurnResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkOpen(); err != nil {
+		return cluedo.TurnResult{}, err
+	}
+
+	result, err := s.play(text)
+	if err != nil {
+		return result, err
+	}
+	s.undone = nil
+	return result, s.save()
+}
+
+func (s *Session) play(text string) (cluedo.TurnResult, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.HasPrefix(text, "#") {
+		return cluedo.TurnResult{}, errors.New("nothing to play")
+	}
+
+	// check the whole transcript so it can always be loaded again
+	lines := append(slices.Clone(s.lines), text)
+	transcript, err := cluedo.ParseTranscript(strings.NewReader(strings.Join(lines, "\n")))
+	if err != nil {
+		return cluedo.TurnResult{}, err
+	}
+	if len(transcript.Lines) != len(lines) {
+		return cluedo.TurnResult{}, fmt.Errorf("`%s` has to be a single transcript line", text)
+	}
+	line := transcript.Lines[len(transcript.Lines)-1]
+
+	if s.game == nil {
+		game, err := transcript.Replay(nil)
+		if err != nil {
+			return cluedo.TurnResult{}, err
+		}
+		s.game = game
+		s.lines = lines
+		return cluedo.TurnResult{}, nil
+	}
+
+	result, err := s.game.PlayLine(line)
+	if err != nil {
+		return result, cluedo.TranscriptError{Line: line.Number, Err: err}
+	}
+	s.lines = lines
+	return result, nil
+}
+
+// Undo takes back the last line played.
+func (s *Session) Undo() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkOpen(); err != nil {
+		return err
+	}
+
+	if len(s.lines) == 0 {
+		return ErrNothingToUndo
+	}
+	last := s.lines[len(s.lines)-1]
+	game, lines, err := replay(s.lines[:len(s.lines)-1])
+	if err != nil {
+		return err
+	}
+	s.game, s.lines = game, lines
+	s.undone = append(s.undone, last)
+	return s.save()
+}
+
+// Redo plays the last line that was undone again.
+func (s *Session) Redo() (cluedo.TurnResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkOpen(); err != nil {
+		return cluedo.TurnResult{}, err
+	}
+
+	if len(s.undone) == 0 {
+		return cluedo.TurnResult{}, ErrNothingToRedo
+	}
+	result, err := s.play(s.undone[len(s.undone)-1])
+	if err != nil {
+		return result, err
+	}
+	s.undone = s.undone[:len(s.undone)-1]
+	return result, s.save()
+}
+
+// replay rebuilds the game from lines. Nothing is changed if it fails part
+// way so the session can be left as it was.
+func replay(lines []string) (*cluedo.Game, []string, error) {
+	rebuilt := &Session{}
+	for _, text := range lines {
+		if _, err := rebuilt.play(text); err != nil {
+			return nil, nil, err
+		}
+	}
+	return rebuilt.game, rebuilt.lines, nil
+}
+
+// the undone lines are kept next to the transcript so they survive between
+// runs
+func (s *Session) redoPath() string {
+	return strings.TrimSuffix(s.path, transcriptExt) + redoExt
+}
+
+// close stops the session from being saved so its files can be moved. It
+// waits for anything being played to finish saving first.
+func (s *Session) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+}
+
+func (s *Session) checkOpen() error {
+	if s.closed {
+		return fmt.Errorf("session `%s` has been archived", s.name)
+	}
+	return nil
+}
+
+func (s *Session) save() error {
+	if err := writeLines(s.path, s.lines); err != nil {
+		return err
+	}
+	if len(s.undone) == 0 {
+		err := os.Remove(s.redoPath())
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return writeLines(s.redoPath(), s.undone)
+}
+
+func (s *Session) load() error {
+	lines, err := readLines(s.path)
+	if err != nil {
+		return err
+	}
+	game, lines, err := replay(lines)
+	if err != nil {
+		return err
+	}
+	s.game, s.lines = game, lines
+
+	undone, err := readLines(s.redoPath())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	s.undone = undone
+	return nil
+}
+
+// writeLines replaces the file at path without leaving it half written.
+func writeLines(path string, lines []string) error {
+	text := ""
+	for _, line := range lines {
+		text += line + "\n"
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(text), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func readLines(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
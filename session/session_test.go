@@ -0,0 +1,229 @@
+package session_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
+	"github.com/moltenwolfcub/cluedoAssistant/session"
+)
+
+var sampleLines = []string{
+	"players alice=5 bob=5 charlie=4",
+	"hand peacock, white, rope, bathroom",
+	"suggest ME: white, dagger, study | pass alice bob | show charlie dagger",
+}
+
+func playAll(t *testing.T, s *session.Session, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		if _, err := s.Play(line); err != nil {
+			t.Fatalf("Session.Play(%q) %v", line, err)
+		}
+	}
+}
+
+func daggerOwner(s *session.Session) string {
+	owner := ""
+	s.Read(func(game *cluedo.Game) {
+		if c, ok := game.Snapshot().Card("dagger"); ok {
+			owner = c.Owner
+		}
+	})
+	return owner
+}
+
+func TestSessionsPersist(t *testing.T) {
+	dir := t.TempDir()
+	manager, err := session.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := manager.Create("table1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	playAll(t, table, sampleLines...)
+
+	practice, err := manager.Create("practice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	playAll(t, practice, sampleLines[:2]...)
+
+	if _, err := manager.Create("practice"); err == nil {
+		t.Error("Manager.Create() Made a second session called practice.")
+	}
+	if _, err := manager.Create("../escape"); err == nil {
+		t.Error("Manager.Create() Allowed a name outside of the directory.")
+	}
+
+	// a new manager only has what was saved to disk
+	manager, err = session.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := manager.Current()
+	if err != nil || current.Name() != "practice" {
+		t.Fatalf("Manager.Current() Expected practice, the last session created, but got %v", err)
+	}
+	table, err = manager.Switch("table1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.Lines()) != 3 || daggerOwner(table) != "charlie" {
+		t.Error("Manager.Switch() table1 wasn't loaded as it was saved.")
+	}
+	if daggerOwner(current) != "" {
+		t.Error("Session.Play() A line played at one table changed another.")
+	}
+}
+
+func TestSessionUndo(t *testing.T) {
+	dir := t.TempDir()
+	manager, _ := session.Open(dir)
+	s, err := manager.Create("game")
+	if err != nil {
+		t.Fatal(err)
+	}
+	playAll(t, s, sampleLines...)
+
+	if err := s.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Lines()) != 2 || daggerOwner(s) != "" {
+		t.Error("Session.Undo() The last suggestion was still in the game.")
+	}
+
+	// undone lines survive being loaded again
+	manager, _ = session.Open(dir)
+	s, _ = manager.Get("game")
+	if _, err := s.Redo(); err != nil {
+		t.Fatal(err)
+	}
+	if daggerOwner(s) != "charlie" {
+		t.Error("Session.Redo() The undone suggestion wasn't played again.")
+	}
+	if _, err := s.Redo(); !errors.Is(err, session.ErrNothingToRedo) {
+		t.Errorf("Session.Redo() Expected nothing to redo but got %v", err)
+	}
+
+	for range 3 {
+		if err := s.Undo(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Undo(); !errors.Is(err, session.ErrNothingToUndo) {
+		t.Errorf("Session.Undo() Expected nothing to undo but got %v", err)
+	}
+}
+
+func TestSessionRejectsBadLines(t *testing.T) {
+	manager, _ := session.Open(t.TempDir())
+	s, _ := manager.Create("game")
+
+	if _, err := s.Play("hand peacock"); err == nil {
+		t.Error("Session.Play() Played a hand before the players.")
+	}
+	playAll(t, s, sampleLines[0])
+	if _, err := s.Play("suggest ME: white, banana, study"); err == nil {
+		t.Error("Session.Play() Played an unknown card.")
+	}
+	if len(s.Lines()) != 1 {
+		t.Errorf("Session.Play() Bad lines were kept: %v", s.Lines())
+	}
+
+	// these mustn't play the last line again
+	playAll(t, s, sampleLines[1:]...)
+	for _, text := range []string{"", "  ", "# bob left the room", sampleLines[2] + "\n" + sampleLines[2]} {
+		if _, err := s.Play(text); err == nil {
+			t.Errorf("Session.Play(%q) Was played as a line.", text)
+		}
+	}
+	if len(s.Lines()) != 3 {
+		t.Errorf("Session.Play() Expected 3 lines but got %v", s.Lines())
+	}
+}
+
+func TestArchive(t *testing.T) {
+	manager, _ := session.Open(t.TempDir())
+	s, _ := manager.Create("finished")
+	playAll(t, s, sampleLines...)
+
+	if err := manager.Archive("finished"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Play("suggest ME: green, rope, garage | pass alice"); err == nil {
+		t.Error("Session.Play() Played into an archived session.")
+	}
+	if _, err := manager.Get("finished"); err == nil {
+		t.Error("Manager.Get() Opened an archived session.")
+	}
+	if _, err := manager.Current(); !errors.Is(err, session.ErrNoCurrent) {
+		t.Errorf("Manager.Current() Archived session was still current: %v", err)
+	}
+
+	infos, err := manager.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 || !infos[0].Archived || infos[0].Lines != 3 {
+		t.Errorf("Manager.List() Expected one archived session with 3 lines but got %+v", infos)
+	}
+
+	if err := manager.Restore("finished"); err != nil {
+		t.Fatal(err)
+	}
+	s, err = manager.Get("finished")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if daggerOwner(s) != "charlie" {
+		t.Error("Manager.Restore() The restored session lost its game.")
+	}
+}
+
+func TestArchiveWhilePlaying(t *testing.T) {
+	dir := t.TempDir()
+	manager, _ := session.Open(dir)
+	s, _ := manager.Create("busy")
+	playAll(t, s, sampleLines[:2]...)
+
+	played := make(chan int)
+	started := sync.WaitGroup{}
+	for range 4 {
+		started.Add(1)
+		go func() {
+			n := 0
+			for {
+				if _, err := s.Play("suggest alice: green, rope, garage | pass bob"); err != nil {
+					played <- n
+					return
+				}
+				if n++; n == 1 {
+					started.Done()
+				}
+			}
+		}()
+	}
+	started.Wait()
+	if err := manager.Archive("busy"); err != nil {
+		t.Fatal(err)
+	}
+
+	total := 0
+	for range 4 {
+		total += <-played
+	}
+	if _, err := os.Stat(filepath.Join(dir, "busy.txt")); err == nil {
+		t.Error("Manager.Archive() A line played while archiving was saved to the old place.")
+	}
+	infos, _ := manager.List()
+	if len(infos) != 1 || infos[0].Lines != 2+total {
+		t.Errorf("Manager.Archive() Expected the archive to have all %d lines but got %+v", 2+total, infos)
+	}
+}
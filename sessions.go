@@ -0,0 +1,148 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
+	"github.com/moltenwolfcub/cluedoAssistant/session"
+)
+
+func defaultSessionDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ".cluedoAssistant"
+	}
+	return filepath.Join(dir, "cluedoAssistant", "sessions")
+}
+
+func sessions(args []string) int {
+	flags := flag.NewFlagSet("session", flag.ContinueOnError)
+	dir := flags.String("dir", defaultSessionDir(), "where sessions are stored")
+	flags.Usage = func() {
+		out := flags.Output()
+		fmt.Fprintln(out, "usage: cluedoAssistant session [-dir dir] <command>")
+		fmt.Fprintln(out, "")
+		fmt.Fprintln(out, "commands:")
+		fmt.Fprintln(out, "  list              list every session")
+		fmt.Fprintln(out, "  new <name>        start a session and switch to it")
+		fmt.Fprintln(out, "  switch <name>     switch to a session")
+		fmt.Fprintln(out, "  archive <name>    put a finished session away")
+		fmt.Fprintln(out, "  restore <name>    bring an archived session back")
+		fmt.Fprintln(out, "  play <line>       play a transcript line in the current session")
+		fmt.Fprintln(out, "  undo              take back the last line")
+		fmt.Fprintln(out, "  redo              play the last undone line again")
+		fmt.Fprintln(out, "  show              print the current session's grid")
+		fmt.Fprintln(out, "")
+		fmt.Fprintln(out, "lines with a | in them have to be quoted so the shell doesn't treat it as a pipe:")
+		fmt.Fprintln(out, "  play 'suggest ME: white, dagger, study | show alice dagger'")
+		fmt.Fprintln(out, "")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	manager, err := session.Open(*dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	command, rest := flags.Arg(0), flags.Args()[1:]
+	needsName := map[string]bool{"new": true, "switch": true, "archive": true, "restore": true}
+	if needsName[command] && len(rest) != 1 {
+		fmt.Fprintf(os.Stderr, "%s needs a session name\n", command)
+		return 2
+	}
+
+	switch command {
+	case "list":
+		err = listSessions(manager)
+	case "new":
+		_, err = manager.Create(rest[0])
+	case "switch":
+		_, err = manager.Switch(rest[0])
+	case "archive":
+		err = manager.Archive(rest[0])
+	case "restore":
+		err = manager.Restore(rest[0])
+	case "play", "undo", "redo", "show":
+		err = useCurrent(manager, command, strings.Join(rest, " "))
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command `%s`\n", command)
+		flags.Usage()
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func listSessions(manager *session.Manager) error {
+	infos, err := manager.List()
+	if err != nil {
+		return err
+	}
+	current, _ := manager.Current()
+
+	for _, info := range infos {
+		marker := " "
+		if current != nil && current.Name() == info.Name && !info.Archived {
+			marker = "*"
+		}
+		archived := ""
+		if info.Archived {
+			archived = " (archived)"
+		}
+		fmt.Printf("%s %s%s: %d lines, last played %s\n", marker, info.Name, archived, info.Lines, info.Modified.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func useCurrent(manager *session.Manager, command string, line string) error {
+	s, err := manager.Current()
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "play":
+		result, err := s.Play(line)
+		if err != nil {
+			return err
+		}
+		fmt.Print(result)
+	case "undo":
+		if err := s.Undo(); err != nil {
+			return err
+		}
+	case "redo":
+		result, err := s.Redo()
+		if err != nil {
+			return err
+		}
+		fmt.Print(result)
+	}
+
+	s.Read(func(game *cluedo.Game) {
+		if game == nil {
+			fmt.Printf("%s has no players yet, start with: play players alice=5 bob=5\n", s.Name())
+			return
+		}
+		if command == "show" || command == "undo" {
+			fmt.Println(game)
+		}
+	})
+	return nil
+}
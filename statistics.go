@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/moltenwolfcub/cluedoAssistant/stats"
+)
+
+func statistics(args []string) int {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	dir := flags.String("dir", defaultSessionDir(), "where the saved games are")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: cluedoAssistant stats [-dir dir] [player...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	s, err := stats.Load(*dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	for _, err := range s.Skipped {
+		fmt.Fprintf(os.Stderr, "skipped %v\n", err)
+	}
+
+	profiles := s.Profiles()
+	if flags.NArg() > 0 {
+		profiles = profiles[:0]
+		for _, name := range flags.Args() {
+			p, ok := s.Profile(name)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "`%s` isn't in any saved game\n", name)
+				return 1
+			}
+			profiles = append(profiles, p)
+		}
+	}
+
+	fmt.Printf("%d games\n", s.Games)
+	for _, p := range profiles {
+		printProfile(p)
+	}
+	return 0
+}
+
+func printProfile(p stats.Profile) {
+	fmt.Printf("\n%s: %d games, %d suggestions\n", p.Name, p.Games, p.Suggestions)
+	if p.KnownCards > 0 {
+		fmt.Printf("  own cards: %.0f%% of %d placed suggested cards\n", 100*p.OwnCardRate(), p.KnownCards)
+	}
+
+	rooms := []string{}
+	for _, r := range p.FavouriteRooms() {
+		if len(rooms) == 3 {
+			break
+		}
+		rooms = append(rooms, fmt.Sprintf("%s (%d)", r.Room, r.Count))
+	}
+	if len(rooms) > 0 {
+		fmt.Printf("  favourite rooms: %s\n", strings.Join(rooms, ", "))
+	}
+
+	if len(p.AccusedAfter) > 0 {
+		fmt.Printf("  accused in %.0f%% of games, after %.1f suggestions on average\n", 100*p.AccuseRate(), p.MeanAccusedAfter())
+	}
+}
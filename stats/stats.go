@@ -0,0 +1,251 @@
+// Package stats learns how regular opponents play from saved games.
+package stats
+
+import (
+	"cmp"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
+)
+
+// MinKnownCards is how many of a player's suggested cards have to have ended
+// up placed before their own card rate is trusted as a prior.
+const MinKnownCards = 10
+
+// Stats is everything learnt from a set of games.
+type Stats struct {
+	Games int
+	// saved games that couldn't be read, each wrapped with its file name
+	Skipped []error
+
+	profiles map[string]*Profile
+}
+
+// Profile is how one player has played across every game they were in.
+type Profile struct {
+	Name        string
+	Games       int
+	Suggestions int
+
+	// suggested cards whose place was known by the end of the game and how
+	// many of those were in the suggester's own hand
+	KnownCards int
+	OwnCards   int
+
+	// how often each room was suggested
+	Rooms map[string]int
+
+	// how many suggestions they'd made before accusing, once for each game
+	// they accused in
+	AccusedAfter []int
+}
+
+type RoomCount struct {
+	Room  string
+	Count int
+}
+
+func New() *Stats {
+	return &Stats{
+		Skipped:  []error{},
+		profiles: map[string]*Profile{},
+	}
+}
+
+// Load reads every saved game in dir and the directories below it. Games that
+// can't be read are skipped and listed in Skipped rather than failing the
+// whole load.
+func Load(dir string) (*Stats, error) {
+	s := New()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".txt" {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		t, err := cluedo.ParseTranscript(f)
+		if err == nil {
+			err = s.Add(t)
+		}
+		if err != nil {
+			s.Skipped = append(s.Skipped, fmt.Errorf("%s: %w", path, err))
+		}
+		return nil
+	})
+	return s, err
+}
+
+// Add learns from one game. Whether a suggested card was the suggester's own
+// is judged by what was known at the end of the game.
+func (s *Stats) Add(t cluedo.Transcript) error {
+	// the whole game is replayed first so a broken game doesn't leave half
+	// its numbers behind
+	game, err := t.Replay(nil)
+	if err != nil {
+		return err
+	}
+
+	suggestions := map[string]int{}
+	seen := map[string]*Profile{}
+	profile := func(name string) *Profile {
+		if p, ok := seen[name]; ok {
+			return p
+		}
+		p := &Profile{Name: name, Rooms: map[string]int{}}
+		seen[name] = p
+		return p
+	}
+
+	for _, line := range t.Lines {
+		switch line.Kind {
+		case cluedo.PlayersLine:
+			for _, p := range line.Players {
+				profile(p.Name).Games++
+			}
+		case cluedo.SuggestLine:
+			if line.Asker == cluedo.MeIdent {
+				continue
+			}
+			p := profile(line.Asker)
+			p.Suggestions++
+			suggestions[line.Asker]++
+			p.Rooms[game.Card(line.Cards[2]).Name()]++
+
+			asker := game.Player(line.Asker)
+			for _, name := range line.Cards {
+				c := game.Card(name)
+				if !c.IsFound() && !c.IsMurderItem() {
+					continue
+				}
+				p.KnownCards++
+				if c.Possessor() == asker {
+					p.OwnCards++
+				}
+			}
+		case cluedo.AccuseLine:
+			if line.Asker == cluedo.MeIdent {
+				continue
+			}
+			p := profile(line.Asker)
+			p.AccusedAfter = append(p.AccusedAfter, suggestions[line.Asker])
+		}
+	}
+
+	for name, p := range seen {
+		s.profiles[name] = s.profile(name).merge(p)
+	}
+	s.Games++
+	return nil
+}
+
+func (s *Stats) profile(name string) *Profile {
+	if p, ok := s.profiles[name]; ok {
+		return p
+	}
+	return &Profile{Name: name, Rooms: map[string]int{}}
+}
+
+func (p *Profile) merge(other *Profile) *Profile {
+	p.Games += other.Games
+	p.Suggestions += other.Suggestions
+	p.KnownCards += other.KnownCards
+	p.OwnCards += other.OwnCards
+	for room, n := range other.Rooms {
+		p.Rooms[room] += n
+	}
+	p.AccusedAfter = append(p.AccusedAfter, other.AccusedAfter...)
+	return p
+}
+
+// Profiles gives everyone that's been played against, sorted by name.
+func (s *Stats) Profiles() []Profile {
+	profiles := []Profile{}
+	for _, p := range s.profiles {
+		profiles = append(profiles, *p)
+	}
+	slices.SortFunc(profiles, func(a, b Profile) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return profiles
+}
+
+func (s *Stats) Profile(name string) (Profile, bool) {
+	p, ok := s.profiles[name]
+	if !ok {
+		return Profile{}, false
+	}
+	return *p, true
+}
+
+// Priors gives the habits of everyone there's enough known about to be worth
+// passing to Game.Probabilities.
+func (s *Stats) Priors() map[string]cluedo.PlayerPrior {
+	priors := map[string]cluedo.PlayerPrior{}
+	for name, p := range s.profiles {
+		if p.KnownCards < MinKnownCards {
+			continue
+		}
+		priors[name] = cluedo.PlayerPrior{
+			OwnCardRate: p.OwnCardRate(),
+		}
+	}
+	return priors
+}
+
+// OwnCardRate is the share of their suggested cards that were their own, out
+// of the ones that ended up placed.
+func (p Profile) OwnCardRate() float64 {
+	if p.KnownCards == 0 {
+		return 0
+	}
+	return float64(p.OwnCards) / float64(p.KnownCards)
+}
+
+// FavouriteRooms lists the rooms they suggest, most suggested first.
+func (p Profile) FavouriteRooms() []RoomCount {
+	rooms := []RoomCount{}
+	for room, n := range p.Rooms {
+		rooms = append(rooms, RoomCount{Room: room, Count: n})
+	}
+	slices.SortFunc(rooms, func(a, b RoomCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Room, b.Room)
+	})
+	return rooms
+}
+
+// AccuseRate is the share of their games they made an accusation in.
+func (p Profile) AccuseRate() float64 {
+	if p.Games == 0 {
+		return 0
+	}
+	return float64(len(p.AccusedAfter)) / float64(p.Games)
+}
+
+// MeanAccusedAfter is how many suggestions they usually make before
+// accusing, or 0 if they've never accused.
+func (p Profile) MeanAccusedAfter() float64 {
+	if len(p.AccusedAfter) == 0 {
+		return 0
+	}
+	total := 0
+	for _, n := range p.AccusedAfter {
+		total += n
+	}
+	return float64(total) / float64(len(p.AccusedAfter))
+}
@@ -0,0 +1,123 @@
+package stats_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
+	"github.com/moltenwolfcub/cluedoAssistant/stats"
+)
+
+var savedGames = map[string][]string{
+	"table1.txt": {
+		"players alice=5 bob=5 charlie=4",
+		"hand peacock, white, rope, bathroom",
+		"suggest ME: white, dagger, study | pass alice bob | show charlie dagger",
+		// peacock is ours and charlie has the dagger so bob has the kitchen
+		"suggest charlie: peacock, dagger, kitchen | pass alice | show bob",
+		"accuse charlie: plum, candlestick, garage",
+	},
+	"archive/table2.txt": {
+		"players charlie=6 dave=6",
+		"hand green, mustard, wrench, candlestick, bathroom, study",
+		"suggest charlie: green, wrench, garage | show ME green",
+		"suggest charlie: plum, pistol, garage | pass dave ME",
+		"suggest dave: scarlet, rope, kitchen | pass ME",
+		"accuse charlie: plum, pistol, garage",
+	},
+	"broken.txt": {
+		"players alice=5 bob=5 charlie=4",
+		"suggest ME: white, banana, study",
+	},
+	"current": {
+		"table1",
+	},
+}
+
+func writeGames(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, lines := range savedGames {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoad(t *testing.T) {
+	s, err := stats.Load(writeGames(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Games != 2 {
+		t.Errorf("Load() Read %d games but expected 2.", s.Games)
+	}
+	if len(s.Skipped) != 1 || !strings.Contains(s.Skipped[0].Error(), "broken.txt") {
+		t.Errorf("Load() Skipped %v but expected only broken.txt.", s.Skipped)
+	}
+
+	names := []string{}
+	for _, p := range s.Profiles() {
+		names = append(names, p.Name)
+	}
+	if !slices.Equal(names, []string{"alice", "bob", "charlie", "dave"}) {
+		t.Errorf("Stats.Profiles() Gave %v.", names)
+	}
+
+	charlie, ok := s.Profile("charlie")
+	if !ok {
+		t.Fatal("Stats.Profile() Didn't find charlie.")
+	}
+	if charlie.Games != 2 || charlie.Suggestions != 3 {
+		t.Errorf("charlie played %d games with %d suggestions but expected 2 and 3.", charlie.Games, charlie.Suggestions)
+	}
+	if !slices.Equal(charlie.AccusedAfter, []int{1, 2}) && !slices.Equal(charlie.AccusedAfter, []int{2, 1}) {
+		t.Errorf("charlie accused after %v suggestions but expected 1 and 2.", charlie.AccusedAfter)
+	}
+	if rate := charlie.AccuseRate(); rate != 1 {
+		t.Errorf("Profile.AccuseRate() Gave %v but charlie accused every game.", rate)
+	}
+	if rooms := charlie.FavouriteRooms(); rooms[0] != (stats.RoomCount{Room: "garage", Count: 2}) {
+		t.Errorf("Profile.FavouriteRooms() Gave %v but charlie suggested the garage most.", rooms)
+	}
+
+	// the dagger was charlie's and peacock and kitchen weren't in the first
+	// game, green and the wrench were ours in the second
+	if charlie.OwnCards != 1 || charlie.KnownCards != 5 {
+		t.Errorf("charlie suggested %d of their own cards out of %d placed but expected 1 of 5.", charlie.OwnCards, charlie.KnownCards)
+	}
+
+	if _, ok := s.Profile(cluedo.MeIdent); ok {
+		t.Error("Stats.Profile() Made a profile for us.")
+	}
+}
+
+func TestPriors(t *testing.T) {
+	s := stats.New()
+	for range stats.MinKnownCards {
+		transcript, err := cluedo.ParseTranscript(strings.NewReader(strings.Join(savedGames["table1.txt"], "\n")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Add(transcript); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	priors := s.Priors()
+	if len(priors) != 1 {
+		t.Fatalf("Stats.Priors() Gave %v but only charlie has enough placed cards.", priors)
+	}
+	if got := priors["charlie"].OwnCardRate; got != 1.0/3 {
+		t.Errorf("Stats.Priors() Gave charlie an own card rate of %v but expected 1/3.", got)
+	}
+}
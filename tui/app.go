@@ -0,0 +1,491 @@
+package tui
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
+)
+
+// how long to spend counting probabilities after each turn before giving up
+const probabilityTimeout = 3 * time.Second
+
+// shown while the side panel is still being counted
+const refining = "refining…"
+
+// the shortest time between redraws for a side panel count's progress.
+// Redrawing holds the lock keys need
+const progressInterval = 200 * time.Millisecond
+
+// how likely a player has to be holding a card they suggested before it's
+// shown as a bluff
+const minBluff = 0.5
+
+// App is the full screen assistant. Transcript lines are typed into the
+// input line and the grid, history and side panel are redrawn after each
+// one.
+type App struct {
+	term Terminal
+	in   *bufio.Reader
+	out  io.Writer
+
+	// held while handling a key or drawing, since the side panel is filled
+	// in from the goroutine counting it
+	mu      sync.Mutex
+	running bool
+	// the screen size, read again after each key rather than on every draw
+	width  int
+	height int
+
+	game *cluedo.Game
+
+	history []entry
+	// how many lines the history pane is scrolled up by
+	scroll int
+
+	input  string
+	status string
+	// set while a question is being entered part by part
+	ask *asking
+
+	side []string
+	// which count the side panel is from. A count that's been replaced by a
+	// newer one leaves the panel alone
+	sideCount int
+	stopSide  context.CancelFunc
+	// closed once the latest count has finished
+	sideDone chan struct{}
+
+	// passed on when counting the probabilities for the side panel
+	Priors    map[string]cluedo.PlayerPrior
+	BluffRate float64
+}
+
+// entry is one line that's been played and what was learnt from it.
+type entry struct {
+	text  string
+	facts []string
+}
+
+func New(term Terminal, in io.Reader, out io.Writer) *App {
+	return &App{
+		term:   term,
+		in:     bufio.NewReader(in),
+		out:    out,
+		status: "start with a players line, like: players alice=5 bob=5 charlie=4",
+	}
+}
+
+// Play plays transcript lines as if they'd been typed in, stopping at the
+// first one that can't be played.
+func (a *App) Play(lines ...string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	defer a.updateSide()
+	for _, text := range lines {
+		if err := a.play(text); err != nil {
+			return fmt.Errorf("%s: %w", text, err)
+		}
+	}
+	a.status = ""
+	return nil
+}
+
+var errQuit = errors.New("quit")
+
+// Run takes over the terminal until the user quits with ctrl-c, ctrl-d or
+// `quit`.
+func (a *App) Run() error {
+	restore, err := a.term.Raw()
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	// use the alternate screen so the shell comes back as it was
+	fmt.Fprint(a.out, "\x1b[?1049h")
+	defer fmt.Fprint(a.out, "\x1b[?1049l")
+
+	a.mu.Lock()
+	a.running = true
+	a.mu.Unlock()
+	// a count that's still going mustn't draw once the terminal is back to
+	// normal
+	defer func() {
+		a.mu.Lock()
+		a.running = false
+		a.mu.Unlock()
+	}()
+
+	for {
+		a.mu.Lock()
+		err := a.resize()
+		if err == nil {
+			err = a.draw()
+		}
+		a.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		if err := a.handleKey(); err != nil {
+			if errors.Is(err, errQuit) || errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (a *App) handleKey() error {
+	r, _, err := a.in.ReadRune()
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.ask != nil && (r == '\r' || r == '\n') {
+		a.submitAsk()
+		return nil
+	}
+	if a.ask != nil && r != '\t' {
+		// check what's been typed as it's typed. Tab shows the options
+		// instead
+		defer func() {
+			if a.ask != nil {
+				a.status = a.askProblem()
+			}
+		}()
+	}
+
+	switch r {
+	case 3, 4: // ctrl-c, ctrl-d
+		return errQuit
+	case '\r', '\n':
+		return a.submit()
+	case 127, 8: // backspace
+		if a.input != "" {
+			runes := []rune(a.input)
+			a.input = string(runes[:len(runes)-1])
+		}
+	case 21: // ctrl-u
+		a.input = ""
+	case '\t':
+		var options []string
+		if a.ask != nil {
+			a.input, options = complete(a.input, a.askOptions())
+		} else {
+			a.input, options = complete(a.input, a.candidates(a.input))
+		}
+		if len(options) > 0 {
+			a.status = strings.Join(options, "  ")
+		}
+	case 27:
+		return a.handleEscape()
+	default:
+		if r >= ' ' {
+			a.input += string(r)
+		}
+	}
+	return nil
+}
+
+// handleEscape deals with arrow and page keys, which scroll the history.
+func (a *App) handleEscape() error {
+	if b, err := a.in.ReadByte(); err != nil || b != '[' {
+		return err
+	}
+	b, err := a.in.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 'A':
+		a.scroll++
+	case 'B':
+		a.scroll--
+	case '5', '6':
+		if _, err := a.in.ReadByte(); err != nil {
+			return err
+		}
+		if b == '5' {
+			a.scroll += 10
+		} else {
+			a.scroll -= 10
+		}
+	}
+	a.scroll = max(a.scroll, 0)
+	return nil
+}
+
+func (a *App) submit() error {
+	text := strings.TrimSpace(a.input)
+	a.input = ""
+	a.status = ""
+	if text == "" {
+		return nil
+	}
+	if text == "quit" {
+		return errQuit
+	}
+	if text == "ask" {
+		if err := a.startAsking(); err != nil {
+			a.status = err.Error()
+		}
+		return nil
+	}
+
+	if err := a.play(text); err != nil {
+		a.status = err.Error()
+		a.input = text
+		return nil
+	}
+	a.scroll = 0
+	a.updateSide()
+	return nil
+}
+
+// play plays one transcript line into the game.
+func (a *App) play(text string) error {
+	line, err := cluedo.ParseTranscriptLine(text)
+	if err != nil {
+		return err
+	}
+
+	if a.game == nil {
+		if line.Kind != cluedo.PlayersLine {
+			return errors.New("the players have to be given first")
+		}
+		game, err := cluedo.Transcript{Lines: []cluedo.TranscriptLine{line}}.Replay(nil)
+		if err != nil {
+			return err
+		}
+		a.game = game
+		a.history = append(a.history, entry{text: text})
+		return nil
+	}
+
+	result, err := a.game.PlayLine(line)
+	if err != nil {
+		return err
+	}
+	e := entry{
+		text: text,
+	}
+	for _, f := range result.Facts {
+		e.facts = append(e.facts, f.String())
+	}
+	a.history = append(a.history, e)
+	return nil
+}
+
+var keywords = []string{"players", "hand", "suggest", "accuse", "ask", "quit"}
+
+// candidates is every name that could be tab completed at the end of text.
+// In a suggestion they're narrowed down to the part being typed, so the
+// cards after the asker go who, what, where.
+func (a *App) candidates(text string) []string {
+	if a.game == nil {
+		return keywords
+	}
+
+	cards := func(category string) []string {
+		names := []string{}
+		for _, c := range a.game.CategoryCards(category) {
+			names = append(names, c.Name())
+		}
+		return names
+	}
+	players := []string{}
+	for _, p := range a.game.TurnOrder() {
+		players = append(players, p.Name())
+	}
+
+	keyword, rest, ok := strings.Cut(text, " ")
+	switch {
+	case !ok:
+		return keywords
+	case keyword == "hand":
+		return slices.Concat(cards("who"), cards("what"), cards("where"))
+	case keyword != "suggest" && keyword != "accuse":
+		return nil
+	}
+
+	parts := strings.Split(rest, "|")
+	if len(parts) == 1 {
+		_, suggested, ok := strings.Cut(rest, ":")
+		if !ok {
+			return players
+		}
+		return cards([]string{"who", "what", "where"}[min(strings.Count(suggested, ","), 2)])
+	}
+
+	last := strings.TrimLeft(parts[len(parts)-1], " ")
+	part, after, ok := strings.Cut(last, " ")
+	if !ok {
+		return []string{"pass", "show"}
+	}
+	if part == "show" && strings.Contains(strings.TrimLeft(after, " "), " ") {
+		// the card that was shown has to be one of the suggested ones
+		_, suggested, _ := strings.Cut(parts[0], ":")
+		return splitNames(suggested)
+	}
+	return players
+}
+
+func splitNames(text string) []string {
+	names := []string{}
+	for _, name := range strings.Split(text, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// updateSide starts working out the recommendations and probabilities for
+// the side panel, stopping any count that's still going. It's only done after
+// a turn because counting can take a while, and it's counted in the
+// background so typing carries on while it does. a.mu has to be held.
+func (a *App) updateSide() {
+	if a.stopSide != nil {
+		a.stopSide()
+	}
+	a.sideCount++
+	done := make(chan struct{})
+	a.sideDone = done
+
+	if a.game == nil {
+		a.side = []string{}
+		close(done)
+		return
+	}
+	a.side = []string{"ENVELOPE " + refining}
+
+	// the game carries on being played while this one is counted
+	game := a.game.Clone()
+	ctx, cancel := context.WithTimeout(context.Background(), probabilityTimeout)
+	a.stopSide = cancel
+	count := a.sideCount
+	// progress is only reported from one goroutine
+	var shown time.Time
+	opts := cluedo.ProbabilityOptions{
+		Priors:    a.Priors,
+		BluffRate: a.BluffRate,
+		Progress: func(p cluedo.Progress) {
+			if time.Since(shown) < progressInterval {
+				return
+			}
+			shown = time.Now()
+			a.setSide(count, slices.Concat(
+				[]string{fmt.Sprintf("ENVELOPE %s %d/%d", refining, p.Done, p.Total)},
+				envelopeLines(p.Partial),
+			))
+		},
+	}
+
+	go func() {
+		defer close(done)
+		defer cancel()
+		a.setSide(count, sideLines(ctx, &game, opts))
+	}()
+}
+
+// setSide replaces the side panel if it's from the latest count and redraws
+// the screen.
+func (a *App) setSide(count int, side []string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if count != a.sideCount {
+		return
+	}
+	a.side = side
+	if a.running {
+		// the next key redraws anyway so a failed draw can be left to it
+		_ = a.draw()
+	}
+}
+
+// sideLines is the whole side panel for game.
+func sideLines(ctx context.Context, game *cluedo.Game, opts cluedo.ProbabilityOptions) []string {
+	probs, err := game.Probabilities(ctx, opts)
+	if errors.Is(err, context.Canceled) {
+		// replaced by a newer count
+		return nil
+	}
+	if err != nil {
+		return []string{"ENVELOPE", "  " + err.Error()}
+	}
+
+	side := append([]string{"ENVELOPE"}, envelopeLines(probs)...)
+
+	if opts.BluffRate > 0 || len(opts.Priors) > 0 {
+		bluffs := []cluedo.Bluff{}
+		for _, b := range game.Bluffs(probs) {
+			// cards that are certainly theirs are already on the grid
+			if b.Held >= minBluff && b.Held < 1 {
+				bluffs = append(bluffs, b)
+			}
+		}
+		if len(bluffs) > 0 {
+			side = append(side, "", "BLUFFS")
+			for _, b := range bluffs[:min(3, len(bluffs))] {
+				side = append(side, fmt.Sprintf("  %s %s x%d %3.0f%%", b.Asker, b.Card, b.Times, b.Held*100))
+			}
+		}
+	}
+
+	side = append(side, "", "NEXT MOVE")
+	room, ok := game.PlayerRoom(game.Me)
+	if !ok {
+		return append(side, "  make a suggestion to", "  plan from your room")
+	}
+	plans, err := game.PlanMove(ctx, room, cluedo.PlanOptions{Probabilities: &probs})
+	if err != nil {
+		return append(side, "  "+err.Error())
+	}
+	side = append(side, "  from the "+room)
+	for _, plan := range plans[:min(3, len(plans))] {
+		via := ""
+		if plan.Passage {
+			via = " (passage)"
+		}
+		side = append(side,
+			fmt.Sprintf("  %s%s %3.0f%%", plan.Room, via, plan.Reach[0]*100),
+			fmt.Sprintf("    %s, %s", plan.Who, plan.What),
+		)
+	}
+	return side
+}
+
+// envelopeLines is the likeliest few cards in each category to be in the
+// envelope.
+func envelopeLines(probs cluedo.Probabilities) []string {
+	lines := []string{}
+	for _, category := range []string{"who", "what", "where"} {
+		cards := []cluedo.CardProbability{}
+		for _, c := range probs.Cards {
+			if c.Category == category && c.Envelope > 0 {
+				cards = append(cards, c)
+			}
+		}
+		slices.SortStableFunc(cards, func(x, y cluedo.CardProbability) int {
+			return cmp.Compare(y.Envelope, x.Envelope)
+		})
+
+		lines = append(lines, category)
+		for _, c := range cards[:min(3, len(cards))] {
+			lines = append(lines, fmt.Sprintf("  %-12s %3.0f%%", c.Name, c.Envelope*100))
+		}
+	}
+	return lines
+}
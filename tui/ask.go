@@ -0,0 +1,189 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/moltenwolfcub/cluedoAssistant/cluedo"
+)
+
+// A question can be entered one part at a time after typing `ask`. Each part
+// only completes and accepts names that fit it and the question isn't played
+// until the game accepts it. Answering `none` records a pass and asks who
+// answered next, until someone shows a card or everyone has passed.
+
+type askStage int
+
+const (
+	askWho askStage = iota
+	askWhat
+	askWhere
+	askAsker
+	askAnswerer
+	askAnswer
+)
+
+var askPrompts = []string{"who", "what", "where", "asker", "answerer", "answer"}
+
+// the answers that aren't a shown card
+const (
+	answerNone    = "none"
+	answerUnknown = "unknown"
+)
+
+type asking struct {
+	stage  askStage
+	values []string
+	// everyone who's passed so far, in the order they were entered
+	passes []string
+}
+
+func (a *App) startAsking() error {
+	if a.game == nil {
+		return fmt.Errorf("the players have to be given first")
+	}
+	a.ask = &asking{}
+	a.status = "enter on an empty line cancels"
+	return nil
+}
+
+// askOptions is every value the current part of the question can have.
+func (a *App) askOptions() []string {
+	options := []string{}
+	switch a.ask.stage {
+	case askWho, askWhat, askWhere:
+		for _, c := range a.game.CategoryCards(askPrompts[a.ask.stage]) {
+			options = append(options, c.Name())
+		}
+	case askAsker, askAnswerer:
+		for _, p := range a.game.TurnOrder() {
+			options = append(options, p.Name())
+		}
+	case askAnswer:
+		if a.ask.values[askAnswerer] == cluedo.MeIdent {
+			// we know which of the cards we could have shown
+			for _, name := range a.ask.values[:askAsker] {
+				if a.game.Card(name).Possessor() == a.game.Me {
+					options = append(options, name)
+				}
+			}
+			if len(options) == 0 {
+				options = append(options, answerNone)
+			}
+			break
+		}
+		options = append(options, answerNone, answerUnknown)
+		// only our own suggestions show us the card
+		if a.ask.values[askAsker] == cluedo.MeIdent {
+			options = append(options, a.ask.values[:askAsker]...)
+		}
+	}
+	return options
+}
+
+// askProblem is why the input can't be accepted for the current part, or ""
+// if it can.
+func (a *App) askProblem() string {
+	text := strings.TrimSpace(a.input)
+	if text == "" {
+		return "enter on an empty line cancels"
+	}
+
+	options := a.askOptions()
+	i := slices.IndexFunc(options, func(o string) bool { return strings.EqualFold(o, text) })
+	if i < 0 {
+		switch a.ask.stage {
+		case askWho, askWhat, askWhere:
+			return fmt.Sprintf("`%s` isn't a %s card", text, askPrompts[a.ask.stage])
+		case askAsker, askAnswerer:
+			return fmt.Sprintf("`%s` isn't playing", text)
+		}
+		return fmt.Sprintf("the answer has to be one of %s", strings.Join(options, ", "))
+	}
+
+	if a.ask.stage == askAnswerer {
+		if slices.Contains(a.ask.passes, options[i]) {
+			return fmt.Sprintf("`%s` has already passed", options[i])
+		}
+		if _, err := a.question(options[i]); err != nil {
+			return err.Error()
+		}
+	}
+	return ""
+}
+
+// question makes the question entered so far with answerer answering it.
+func (a *App) question(answerer string) (cluedo.Question, error) {
+	v := a.ask.values
+	return a.game.QuestionFor(
+		a.game.Card(v[askWho]),
+		a.game.Card(v[askWhat]),
+		a.game.Card(v[askWhere]),
+		a.game.Player(v[askAsker]),
+		a.game.Player(answerer),
+	)
+}
+
+func (a *App) submitAsk() {
+	text := strings.TrimSpace(a.input)
+	if text == "" {
+		a.ask = nil
+		a.status = "question cancelled"
+		return
+	}
+	if problem := a.askProblem(); problem != "" {
+		a.status = problem
+		return
+	}
+
+	options := a.askOptions()
+	value := options[slices.IndexFunc(options, func(o string) bool { return strings.EqualFold(o, text) })]
+	a.ask.values = append(a.ask.values, value)
+	a.input = ""
+	a.status = ""
+
+	if a.ask.stage < askAnswer {
+		a.ask.stage++
+		return
+	}
+
+	if value == answerNone {
+		a.ask.passes = append(a.ask.passes, a.ask.values[askAnswerer])
+		a.ask.values = a.ask.values[:askAnswerer]
+		a.ask.stage = askAnswerer
+		// the question carries on round the table until everyone else has
+		// passed
+		if len(a.ask.passes) < len(a.game.TurnOrder())-1 {
+			a.status = "passed: " + strings.Join(a.ask.passes, " ")
+			return
+		}
+	}
+
+	a.playAsk()
+	a.ask = nil
+	a.scroll = 0
+	a.updateSide()
+}
+
+// playAsk plays the finished question as a transcript line and records it in
+// the history.
+func (a *App) playAsk() {
+	v := a.ask.values
+	text := fmt.Sprintf("suggest %s: %s, %s, %s", v[askAsker], v[askWho], v[askWhat], v[askWhere])
+	if len(a.ask.passes) > 0 {
+		text += " | pass " + strings.Join(a.ask.passes, " ")
+	}
+	if len(v) > int(askAnswer) {
+		switch v[askAnswer] {
+		case answerUnknown:
+			text += " | show " + v[askAnswerer]
+		default:
+			text += " | show " + v[askAnswerer] + " " + v[askAnswer]
+		}
+	}
+
+	if err := a.play(text); err != nil {
+		a.status = err.Error()
+	}
+}
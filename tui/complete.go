@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"strings"
+)
+
+// complete finishes the name being typed at the end of text. Names can
+// have spaces in them so every word boundary since the last separator is
+// tried, longest first. If more than one name fits it's completed as far as
+// they agree and the options are returned.
+func complete(text string, names []string) (string, []string) {
+	start := strings.LastIndexAny(text, ",:|") + 1
+
+	for i := start; i <= len(text); i++ {
+		if i > start && text[i-1] != ' ' {
+			continue
+		}
+		fragment := strings.TrimLeft(text[i:], " ")
+		if fragment == "" {
+			continue
+		}
+
+		matches := []string{}
+		for _, name := range names {
+			if strings.HasPrefix(strings.ToLower(name), strings.ToLower(fragment)) {
+				matches = append(matches, name)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+
+		prefix := commonPrefix(matches)
+		completed := text[:len(text)-len(fragment)] + prefix
+		if len(matches) == 1 {
+			return completed, nil
+		}
+		return completed, matches
+	}
+	return text, nil
+}
+
+func commonPrefix(names []string) string {
+	prefix := names[0]
+	for _, name := range names[1:] {
+		n := 0
+		for n < len(prefix) && n < len(name) && strings.EqualFold(prefix[n:n+1], name[n:n+1]) {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	return prefix
+}
@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// the fewest rows the history pane gets however tall the grid is
+const minHistoryRows = 3
+
+// resize reads the size of the screen for the next draws.
+func (a *App) resize() error {
+	width, height, err := a.term.Size()
+	if err != nil {
+		return err
+	}
+	a.width, a.height = width, height
+	return nil
+}
+
+func (a *App) draw() error {
+	width, height := a.width, a.height
+
+	screen := strings.Builder{}
+	screen.WriteString("\x1b[H")
+	for i, line := range a.render(width, height) {
+		if i > 0 {
+			screen.WriteString("\r\n")
+		}
+		screen.WriteString(line + "\x1b[K")
+	}
+	// leave the cursor at the end of the input
+	fmt.Fprintf(&screen, "\x1b[%d;%dH", height, min(width, utf8.RuneCountInString(a.prompt()+a.input)+1))
+
+	_, err := io.WriteString(a.out, screen.String())
+	return err
+}
+
+// render lays out the whole screen as height lines of at most width
+// characters. The grid and side panel are at the top, the history fills the
+// middle and the status and input lines are at the bottom.
+func (a *App) render(width, height int) []string {
+	grid := []string{"no game yet"}
+	if a.game != nil {
+		grid = strings.Split(strings.TrimRight(a.game.String(), "\n"), "\n")
+	}
+	gridWidth := 0
+	for _, line := range grid {
+		gridWidth = max(gridWidth, utf8.RuneCountInString(line))
+	}
+
+	topRows := min(max(len(grid), len(a.side)), height-minHistoryRows-3)
+	historyRows := height - topRows - 3
+
+	lines := []string{}
+	for i := range max(topRows, 0) {
+		left, right := "", ""
+		if i < len(grid) {
+			left = grid[i]
+		}
+		if i < len(a.side) {
+			right = a.side[i]
+		}
+		lines = append(lines, pad(left, gridWidth)+"  "+right)
+	}
+
+	title := "── history "
+	if a.scroll > 0 {
+		title = fmt.Sprintf("── history (scrolled up %d) ", a.scroll)
+	}
+	lines = append(lines, title+strings.Repeat("─", max(width-utf8.RuneCountInString(title), 0)))
+
+	history := a.historyLines()
+	a.scroll = min(a.scroll, max(len(history)-historyRows, 0))
+	end := len(history) - a.scroll
+	start := max(end-historyRows, 0)
+	shown := history[start:end]
+	for range historyRows - len(shown) {
+		lines = append(lines, "")
+	}
+	lines = append(lines, shown...)
+
+	lines = append(lines, a.status, a.prompt()+a.input)
+
+	for i, line := range lines {
+		lines[i] = truncate(line, width)
+	}
+	return lines
+}
+
+func (a *App) historyLines() []string {
+	lines := []string{}
+	for _, e := range a.history {
+		lines = append(lines, e.text)
+		for _, f := range e.facts {
+			lines = append(lines, "  "+f)
+		}
+	}
+	return lines
+}
+
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-utf8.RuneCountInString(s), 0))
+}
+
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	return string([]rune(s)[:max(width, 0)])
+}
+
+func (a *App) prompt() string {
+	if a.ask != nil {
+		return askPrompts[a.ask.stage] + "> "
+	}
+	return "> "
+}
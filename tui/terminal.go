@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Terminal is the screen the app draws on. It's an interface so the app can
+// be driven without a real terminal.
+type Terminal interface {
+	// Size is the width and height of the screen in characters.
+	Size() (int, int, error)
+	// Raw switches off line buffering and echo. The returned function puts
+	// the terminal back how it was.
+	Raw() (func() error, error)
+}
+
+// StdTerminal is the terminal attached to stdin. It's changed with stty so it
+// works anywhere stty does, including over SSH.
+type StdTerminal struct{}
+
+func (StdTerminal) stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+func (t StdTerminal) Size() (int, int, error) {
+	out, err := t.stty("size")
+	if err != nil {
+		return 0, 0, err
+	}
+	rows, cols, ok := strings.Cut(out, " ")
+	if !ok {
+		return 0, 0, errors.New("couldn't read the terminal size")
+	}
+	height, err := strconv.Atoi(rows)
+	if err != nil {
+		return 0, 0, err
+	}
+	width, err := strconv.Atoi(cols)
+	if err != nil {
+		return 0, 0, err
+	}
+	return width, height, nil
+}
+
+func (t StdTerminal) Raw() (func() error, error) {
+	saved, err := t.stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("stdin isn't a terminal: %w", err)
+	}
+	if _, err := t.stty("raw", "-echo"); err != nil {
+		return nil, err
+	}
+	return func() error {
+		_, err := t.stty(saved)
+		return err
+	}, nil
+}
@@ -0,0 +1,323 @@
+package tui
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type fakeTerminal struct {
+	width  int
+	height int
+}
+
+func (t fakeTerminal) Size() (int, int, error) {
+	return t.width, t.height, nil
+}
+
+func (t fakeTerminal) Raw() (func() error, error) {
+	return func() error { return nil }, nil
+}
+
+// sizeCounter counts how often the screen size is read.
+type sizeCounter struct {
+	fakeTerminal
+	reads int
+}
+
+func (t *sizeCounter) Size() (int, int, error) {
+	t.reads++
+	return t.fakeTerminal.Size()
+}
+
+// waitSide waits for the side panel to finish counting.
+func (a *App) waitSide() {
+	a.mu.Lock()
+	done := a.sideDone
+	a.mu.Unlock()
+	if done != nil {
+		<-done
+	}
+}
+
+func TestComplete(t *testing.T) {
+	names := []string{"suggest", "show", "alice", "lead pipe", "living room", "library"}
+
+	tests := []struct {
+		text    string
+		want    string
+		options []string
+	}{
+		{"sug", "suggest", nil},
+		{"suggest al", "suggest alice", nil},
+		{"suggest ME: white, lea", "suggest ME: white, lead pipe", nil},
+		{"suggest ME: white, lead pipe, li", "suggest ME: white, lead pipe, li", []string{"living room", "library"}},
+		{"suggest ME: white, lead pipe, liv", "suggest ME: white, lead pipe, living room", nil},
+		{"suggest ME: white, lead pipe, study | SH", "suggest ME: white, lead pipe, study | show", nil},
+		{"zzz", "zzz", nil},
+	}
+	for _, test := range tests {
+		got, options := complete(test.text, names)
+		if got != test.want || !slices.Equal(options, test.options) {
+			t.Errorf("complete(%q) = %q %v, want %q %v", test.text, got, options, test.want, test.options)
+		}
+	}
+}
+
+func TestSession(t *testing.T) {
+	input := strings.Join([]string{
+		"players alice=5 bob=5 charlie=4\r",
+		"hand pea\t, white, rope, bathroom\r",
+		"suggest ME: white, dagger, study | pass alice bob | show charlie dagger\r",
+		"suggest ME: white, banana, study\r",
+		"\x04",
+	}, "")
+	out := bytes.Buffer{}
+	app := New(fakeTerminal{100, 40}, strings.NewReader(input), &out)
+
+	if err := app.Run(); err != nil {
+		t.Fatal(err)
+	}
+	app.waitSide()
+
+	screen := strings.Join(app.render(100, 100), "\n")
+	for _, want := range []string{
+		"GAME",
+		"hand peacock, white, rope, bathroom",
+		"  charlie has dagger",
+		"ENVELOPE",
+		"NEXT MOVE",
+		"unknown card `banana`",
+		"> suggest ME: white, banana, study",
+	} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("App.render() Screen is missing %q:\n%s", want, screen)
+		}
+	}
+
+	for i, line := range app.render(30, 12) {
+		if len([]rune(line)) > 30 {
+			t.Errorf("App.render() Line %d is wider than the screen: %q", i, line)
+		}
+	}
+	if lines := app.render(30, 12); len(lines) != 12 {
+		t.Errorf("App.render() Expected 12 lines but drew %d", len(lines))
+	}
+}
+
+func TestPlayersFirst(t *testing.T) {
+	app := New(fakeTerminal{80, 24}, strings.NewReader(""), &bytes.Buffer{})
+
+	if err := app.Play("hand peacock"); err == nil {
+		t.Error("App.Play() Played a hand before the players were given.")
+	}
+	if err := app.Play("players alice=9 bob=9"); err != nil {
+		t.Fatal(err)
+	}
+	if app.game == nil || len(app.history) != 1 {
+		t.Error("App.Play() The players line didn't start a game.")
+	}
+}
+
+func TestCandidatesFollowSuggestion(t *testing.T) {
+	app := New(fakeTerminal{80, 24}, strings.NewReader(""), &bytes.Buffer{})
+	if err := app.Play("players alice=5 bob=5 charlie=4"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"suggest al", "suggest alice"},
+		{"suggest ME: p", "suggest ME: p"},
+		{"suggest ME: pe", "suggest ME: peacock"},
+		{"suggest ME: white, d", "suggest ME: white, dagger"},
+		{"suggest ME: white, dagger, d", "suggest ME: white, dagger, dining room"},
+		{"suggest ME: white, dagger, study | p", "suggest ME: white, dagger, study | pass"},
+		{"suggest ME: white, dagger, study | pass alice b", "suggest ME: white, dagger, study | pass alice bob"},
+		{"suggest ME: white, dagger, study | show charlie s", "suggest ME: white, dagger, study | show charlie study"},
+	}
+	for _, test := range tests {
+		if got, _ := complete(test.text, app.candidates(test.text)); got != test.want {
+			t.Errorf("complete(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestAskStages(t *testing.T) {
+	input := strings.Join([]string{
+		"ask\r",
+		// a what card isn't accepted as the who
+		"rope\r",
+		"\x15wh\t\r",
+		"dag\t\r",
+		"stu\t\r",
+		"ME\r",
+		// can't answer our own question
+		"ME\r",
+		"\x15charlie\r",
+		"dagger\r",
+		"\x04",
+	}, "")
+	app := New(fakeTerminal{100, 60}, strings.NewReader(input), &bytes.Buffer{})
+	if err := app.Play("players alice=5 bob=5 charlie=4", "hand peacock, white, rope, bathroom"); err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := []string{}
+	for {
+		err := app.handleKey()
+		if app.ask != nil && app.status != "" {
+			statuses = append(statuses, app.status)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	for _, want := range []string{"`rope` isn't a who card", "`ME` can't answer their own question"} {
+		if !slices.Contains(statuses, want) {
+			t.Errorf("App.askProblem() %q was never shown, only %q", want, statuses)
+		}
+	}
+
+	if app.ask != nil {
+		t.Fatal("App.submitAsk() Question was still being entered after the answer.")
+	}
+	last := app.history[len(app.history)-1]
+	if last.text != "suggest ME: white, dagger, study | show charlie dagger" || !slices.Contains(last.facts, "charlie has dagger") {
+		t.Errorf("App.playAsk() Question wasn't played as expected: %+v", last)
+	}
+}
+
+func TestAskTabShowsOptions(t *testing.T) {
+	app := New(fakeTerminal{100, 60}, strings.NewReader("ask\rp\t"), &bytes.Buffer{})
+	if err := app.Play("players alice=5 bob=5 charlie=4"); err != nil {
+		t.Fatal(err)
+	}
+	for range len("ask\rp\t") {
+		if err := app.handleKey(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if app.status != "peacock  plum" {
+		t.Errorf("App.handleKey() Expected tab to show the who cards starting with p but the status was %q", app.status)
+	}
+}
+
+func TestAskPassesThenShow(t *testing.T) {
+	input := strings.Join([]string{
+		"ask\r", "plum\r", "rope\r", "kitchen\r", "bob\r",
+		"charlie\r", "none\r",
+		// charlie's already been asked
+		"charlie\r",
+		"\x15alice\r", "none\r",
+		// we've got the rope so that's all we could have shown
+		"ME\r", "none\r",
+		"\x15ro\t\r",
+		"ask\r", "green\r", "dagger\r", "study\r", "alice\r",
+		"bob\r", "none\r", "charlie\r", "none\r", "ME\r", "none\r",
+		"\x04",
+	}, "")
+	app := New(fakeTerminal{100, 60}, strings.NewReader(input), &bytes.Buffer{})
+	if err := app.Play("players alice=5 bob=5 charlie=4", "hand peacock, white, rope, bathroom"); err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := []string{}
+	for {
+		err := app.handleKey()
+		if app.ask != nil && app.status != "" {
+			statuses = append(statuses, app.status)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	for _, want := range []string{"`charlie` has already passed", "the answer has to be one of rope"} {
+		if !slices.Contains(statuses, want) {
+			t.Errorf("App.askProblem() %q was never shown, only %q", want, statuses)
+		}
+	}
+
+	texts := []string{}
+	for _, e := range app.history[2:] {
+		texts = append(texts, e.text)
+	}
+	want := []string{
+		"suggest bob: plum, rope, kitchen | pass charlie alice | show ME rope",
+		"suggest alice: green, dagger, study | pass bob charlie ME",
+	}
+	if !slices.Equal(texts, want) {
+		t.Errorf("App.playAsk() Expected the questions to be played as %q but got %q", want, texts)
+	}
+	if app.game.Card("kitchen").Possessor() != nil || !slices.Contains(app.game.Card("plum").NonPossessors(), app.game.Player("alice")) {
+		t.Error("App.playAsk() The passes weren't played into the game.")
+	}
+}
+
+func TestSideShowsBluffs(t *testing.T) {
+	app := New(fakeTerminal{100, 60}, strings.NewReader(""), &bytes.Buffer{})
+	app.BluffRate = 0.6
+	suggestion := "suggest bob: plum, dagger, kitchen | show charlie"
+	if err := app.Play("players alice=5 bob=5 charlie=4", "hand peacock, white, rope, bathroom", suggestion, suggestion, suggestion); err != nil {
+		t.Fatal(err)
+	}
+	app.waitSide()
+
+	i := slices.Index(app.side, "BLUFFS")
+	if i < 0 || i+1 == len(app.side) || !strings.HasPrefix(app.side[i+1], "  bob ") {
+		t.Errorf("App.updateSide() bob's repeated suggestion wasn't shown as a bluff: %q", app.side)
+	}
+}
+
+func TestSideCountedInBackground(t *testing.T) {
+	out := bytes.Buffer{}
+	app := New(fakeTerminal{100, 60}, strings.NewReader(""), &out)
+	app.running = true
+	if err := app.resize(); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Play("players alice=5 bob=5 charlie=4", "hand peacock, white, rope, bathroom"); err != nil {
+		t.Fatal(err)
+	}
+	first := app.sideDone
+
+	// playing another line straight away replaces the count for the hand
+	if err := app.Play("suggest ME: white, dagger, study | pass alice | show bob"); err != nil {
+		t.Fatal(err)
+	}
+	<-first
+	app.waitSide()
+
+	if !slices.Contains(app.side, "  from the study") {
+		t.Errorf("App.updateSide() The side panel wasn't from the latest line: %q", app.side)
+	}
+	if !strings.Contains(out.String(), "ENVELOPE "+refining) {
+		t.Error("App.updateSide() The screen wasn't redrawn while the count was being refined.")
+	}
+	if strings.Contains(strings.Join(app.side, "\n"), refining) {
+		t.Errorf("App.updateSide() The finished side panel still says it's refining: %q", app.side)
+	}
+}
+
+func TestSideRedrawsKeepSize(t *testing.T) {
+	term := &sizeCounter{fakeTerminal: fakeTerminal{100, 60}}
+	app := New(term, strings.NewReader(""), &bytes.Buffer{})
+	app.running = true
+	if err := app.resize(); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Play("players alice=5 bob=5 charlie=4", "hand peacock, white, rope, bathroom"); err != nil {
+		t.Fatal(err)
+	}
+	app.waitSide()
+
+	if term.reads != 1 {
+		t.Errorf("App.draw() Redrawing for the side panel read the screen size %d times", term.reads-1)
+	}
+}